@@ -1,15 +1,22 @@
 package zconfigcheck
 
 import (
+	"fmt"
+	"go/ast"
 	"go/token"
 	"go/types"
+	"sort"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/buildssa"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
 	"golang.org/x/tools/go/callgraph/static"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
 )
 
 const (
@@ -33,6 +40,9 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		PkgStructs: make(map[types.Type]StructInfo),
 	}
 
+	// Parse the directives silencing issues before any issue is reported
+	c.collectDirectives()
+
 	// Scan all package structs and their dependencies
 	c.checkStructs()
 
@@ -40,7 +50,13 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	c.lookupInitCalls()
 
 	// Find calls to zconfig to detect which structs are used as configurable root
-	c.detectCalls()
+	if err := c.detectCalls(); err != nil {
+		return nil, err
+	}
+
+	c.reportUnusedDirectives()
+
+	c.flushDiagnostics()
 
 	return nil, nil
 }
@@ -51,25 +67,180 @@ type checker struct {
 	SSA        *buildssa.SSA
 	PkgStructs map[types.Type]StructInfo
 
+	directives []*directive
+
+	// diagnostics are reported once the whole package is checked, sorted by position and message
+	diagnostics []analysis.Diagnostic
+
+	// fields must only be accessed via the astField method
+	fields map[token.Pos]*ast.Field
+
+	// calls must only be accessed via the astCall method
+	calls map[token.Pos]*ast.CallExpr
+
+	// files must only be accessed via the file method
+	files map[string]*token.File
+
 	// callGraph must only be accessed via the CallGraph method
 	callGraph *callgraph.Graph
+
+	// usedVars must only be accessed via the isRead method
+	usedVars map[types.Object]bool
 }
 
+// CallGraph returns the call graph of the package, built with the algorithm set by the callgraph option.
+// The static algorithm is used instead of more precise ones when the package has more functions than
+// allowed by the callgraph-max-funcs option.
 func (c *checker) CallGraph() *callgraph.Graph {
 	if c.callGraph != nil {
 		return c.callGraph
 	}
 
-	c.callGraph = static.CallGraph(c.SSA.Pkg.Prog)
+	prog := c.SSA.Pkg.Prog
+
+	algorithm := callGraphAlgorithm
+	if algorithm != staticCallGraph && callGraphMaxFuncs > 0 {
+		// only functions with a body are analyzed, i.e. functions of the package and synthetic ones
+		var count int
+		for fn := range ssautil.AllFunctions(prog) {
+			if fn.Blocks != nil {
+				count++
+			}
+		}
+		if count > callGraphMaxFuncs {
+			algorithm = staticCallGraph
+		}
+	}
+
+	switch algorithm {
+	case chaCallGraph:
+		c.callGraph = cha.CallGraph(prog)
+	case vtaCallGraph:
+		c.callGraph = vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+	default:
+		c.callGraph = static.CallGraph(prog)
+		addStaticCalls(c.callGraph, c.SSA.SrcFuncs)
+	}
 	return c.callGraph
 }
 
+// addStaticCalls adds the given functions and the static calls they make to the call graph. The static
+// algorithm only includes functions reachable from package-level ones, which misses closures which are
+// never called directly, e.g. returned ones, while they can still call zconfig.
+func addStaticCalls(graph *callgraph.Graph, funcs []*ssa.Function) {
+	seen := make(map[*ssa.Function]bool)
+	for fn := range graph.Nodes {
+		seen[fn] = true
+	}
+
+	var visit func(fn *ssa.Function)
+	visit = func(fn *ssa.Function) {
+		if seen[fn] {
+			return
+		}
+		seen[fn] = true
+
+		node := graph.CreateNode(fn)
+		for _, block := range fn.Blocks {
+			for _, instr := range block.Instrs {
+				site, ok := instr.(ssa.CallInstruction)
+				if !ok {
+					continue
+				}
+				if callee := site.Common().StaticCallee(); callee != nil {
+					callgraph.AddEdge(node, site, graph.CreateNode(callee))
+					visit(callee)
+				}
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		visit(fn)
+	}
+}
+
+// Issue is an issue detected by a given check.
+// Issues are stored in facts, so they must only contain types which can be encoded by the gob package.
+// Suggested fixes are unexported, so that they are ignored by the gob package.
+type Issue struct {
+	Check   Check
+	Message string
+
+	// Position is the position where the issue was detected.
+	// It is only set on issues stored in facts, whose token.Pos are not valid in other packages.
+	Position Position
+
+	fixes   []analysis.SuggestedFix
+	related []analysis.RelatedInformation
+}
+
+// WithFixes returns a copy of the issue with the given suggested fixes
+func (i Issue) WithFixes(fixes ...analysis.SuggestedFix) Issue {
+	i.fixes = append(i.fixes[:len(i.fixes):len(i.fixes)], fixes...)
+	return i
+}
+
+// WithRelated returns a copy of the issue with related information at the given position
+func (i Issue) WithRelated(pos token.Pos, format string, args ...any) Issue {
+	return i.WithRelatedRange(pos, token.NoPos, format, args...)
+}
+
+// WithRelatedRange returns a copy of the issue with related information between the given positions.
+// When the end is not valid, it is computed from the syntax of the package when the issue is reported.
+func (i Issue) WithRelatedRange(pos, end token.Pos, format string, args ...any) Issue {
+	i.related = append(i.related[:len(i.related):len(i.related)], analysis.RelatedInformation{
+		Pos:     pos,
+		End:     end,
+		Message: fmt.Sprintf(format, args...),
+	})
+	return i
+}
+
+// WithoutFixes returns a copy of the issue without suggested fixes. It must be used when
+// the issue is reported at another position than the one it was detected at.
+func (i Issue) WithoutFixes() Issue {
+	i.fixes = nil
+	return i
+}
+
+// Diagnostic converts the issue into a diagnostic reported at the given position.
+// The diagnostic category is the check name, and its message is prefixed with the check code.
+func (i Issue) Diagnostic(pos token.Pos) analysis.Diagnostic {
+	return analysis.Diagnostic{
+		Pos:            pos,
+		Category:       string(i.Check),
+		Message:        fmt.Sprintf("%s: %s", i.Check.Code(), i.Message),
+		SuggestedFixes: i.fixes,
+		Related:        i.related,
+	}
+}
+
+func newIssue(check Check, format string, args ...any) Issue {
+	return Issue{
+		Check:   check,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
 // Issues is a collection of detected issues grouped by their position in the source code
-type Issues map[token.Pos][]string
+type Issues map[token.Pos][]Issue
 
-// Add adds one or more issues for a given source code position
-func (i Issues) Add(pos token.Pos, issues ...string) {
+// Add adds one or more issues for a given source code position.
+// The issues of each position are kept sorted by message.
+func (i Issues) Add(pos token.Pos, issues ...Issue) {
 	i[pos] = append(i[pos], issues...)
+	sort.SliceStable(i[pos], func(a, b int) bool { return i[pos][a].Message < i[pos][b].Message })
+}
+
+// Positions returns the positions of the collection in ascending order
+func (i Issues) Positions() []token.Pos {
+	positions := make([]token.Pos, 0, len(i))
+	for pos := range i {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(a, b int) bool { return positions[a] < positions[b] })
+	return positions
 }
 
 // Merge merges the receiver with the argument, returning a new Issues which
@@ -86,11 +257,50 @@ func (i Issues) Merge(o Issues) Issues {
 	return merged
 }
 
-// Report is a helper to simplify reporting all contained issues
-func (i Issues) Report(pass *analysis.Pass) {
-	for pos, issues := range i {
-		for _, issue := range issues {
-			pass.Reportf(pos, issue)
+// report reports the given issues at the given position, unless they are silenced by
+// a directive or their check is disabled
+func (c *checker) report(pos token.Pos, issues ...Issue) {
+	for _, issue := range issues {
+		if c.ignored(pos, issue) || !issue.Check.Enabled() {
+			continue
+		}
+		diagnostic := issue.Diagnostic(pos)
+		diagnostic.End = c.end(pos)
+		if len(diagnostic.Related) > 0 {
+			// the related information is shared by all the copies of the issue, e.g. when an issue stored
+			// in a fact is reported at each call site, so it is copied before being modified
+			related := make([]analysis.RelatedInformation, len(diagnostic.Related))
+			for i, info := range diagnostic.Related {
+				if !info.End.IsValid() {
+					info.End = c.end(info.Pos)
+				}
+				related[i] = info
+			}
+			diagnostic.Related = related
+		}
+		c.diagnostics = append(c.diagnostics, diagnostic)
+	}
+}
+
+// reportIssues is a helper to simplify reporting all issues of a collection
+func (c *checker) reportIssues(issues Issues) {
+	for _, pos := range issues.Positions() {
+		c.report(pos, issues[pos]...)
+	}
+}
+
+// flushDiagnostics reports all the diagnostics of the package, sorted by position and then by message,
+// so that the output does not depend on the order in which maps and the call graph are walked.
+func (c *checker) flushDiagnostics() {
+	sort.SliceStable(c.diagnostics, func(i, j int) bool {
+		if c.diagnostics[i].Pos != c.diagnostics[j].Pos {
+			return c.diagnostics[i].Pos < c.diagnostics[j].Pos
 		}
+		return c.diagnostics[i].Message < c.diagnostics[j].Message
+	})
+
+	for _, diagnostic := range c.diagnostics {
+		c.Pass.Report(diagnostic)
 	}
+	c.diagnostics = nil
 }
@@ -1,20 +1,27 @@
 package zconfigcheck_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/synthesio/zconfigcheck"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
-func TestAnalyzer(t *testing.T) {
+// testdata returns the absolute path of the testdata directory
+func testdata(t *testing.T) string {
 	wd, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("Failed to get wd: %s", err)
 	}
-	testdata := filepath.Join(wd, "testdata")
+	return filepath.Join(wd, "testdata")
+}
+
+func TestAnalyzer(t *testing.T) {
+	testdata := testdata(t)
 
 	for testName, pkgName := range map[string]string{
 		"call argument detection": "/call_arg",
@@ -24,9 +31,272 @@ func TestAnalyzer(t *testing.T) {
 		"injection":               "injection",
 		"keys":                    "keys",
 		"dependency cycles":       "cycles",
+		"default values":          "defaults",
+		"parsers":                 "parsers",
+		"ignore directives":       "directives",
+		"argument dataflow":       "dataflow",
+		"ignored errors":          "ignored_errors",
+		"multiple configure":      "multiple_configure",
+		"generic roots":           "scope",
+		"suggestions":             "suggestions",
 	} {
 		t.Run(testName, func(t *testing.T) {
 			analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/"+pkgName)
 		})
 	}
 }
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := testdata(t)
+
+	analysistest.RunWithSuggestedFixes(t, testdata, zconfigcheck.Analyzer, "testdata/src/fixes")
+}
+
+func TestSchema(t *testing.T) {
+	testdata := testdata(t)
+
+	dir := t.TempDir()
+	if err := zconfigcheck.Analyzer.Flags.Set("schema", dir); err != nil {
+		t.Fatalf("Failed to set flag: %s", err)
+	}
+	defer zconfigcheck.Analyzer.Flags.Set("schema", "")
+
+	analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/schema")
+
+	expected, err := filepath.Glob(filepath.Join(testdata, "src/schema/testdata/*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list expected schemas: %s", err)
+	}
+
+	written, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list written schemas: %s", err)
+	}
+	if len(written) != len(expected) {
+		t.Errorf("Expected %d schemas, got %d: %v", len(expected), len(written), written)
+	}
+
+	for _, path := range expected {
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read expected schema: %s", err)
+		}
+
+		got, err := os.ReadFile(filepath.Join(dir, filepath.Base(path)))
+		if err != nil {
+			t.Errorf("Failed to read schema: %s", err)
+			continue
+		}
+
+		if string(got) != string(want) {
+			t.Errorf("Unexpected schema %s:\n%s", filepath.Base(path), got)
+		}
+	}
+}
+
+func TestParsableTypes(t *testing.T) {
+	testdata := testdata(t)
+
+	flag := "parsable-types"
+	types := "testdata/src/parsable_types.Port,testdata/src/parsable_types/subpackage.Custom, map[string]string"
+	if err := zconfigcheck.Analyzer.Flags.Set(flag, types); err != nil {
+		t.Fatalf("Failed to set flag: %s", err)
+	}
+	defer zconfigcheck.Analyzer.Flags.Set(flag, "")
+
+	analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/parsable_types")
+}
+
+func TestCallGraph(t *testing.T) {
+	testdata := testdata(t)
+
+	defer zconfigcheck.Analyzer.Flags.Set("callgraph", "static")
+
+	if err := zconfigcheck.Analyzer.Flags.Set("callgraph", "unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown algorithm")
+	}
+
+	for _, algorithm := range []string{"cha", "vta"} {
+		t.Run(algorithm, func(t *testing.T) {
+			if err := zconfigcheck.Analyzer.Flags.Set("callgraph", algorithm); err != nil {
+				t.Fatalf("Failed to set flag: %s", err)
+			}
+			analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/callgraph")
+		})
+	}
+}
+
+func TestRequiredKeys(t *testing.T) {
+	testdata := testdata(t)
+
+	if err := zconfigcheck.Analyzer.Flags.Set("required-keys-allowlist", "missing.txt"); err == nil {
+		t.Errorf("Expected an error for a missing allowlist")
+	}
+
+	// relative paths are resolved when the flag is set, instead of when the allowlist is read by each package
+	flags := map[string]string{
+		"enable":                  "required-key",
+		"required-keys-allowlist": "testdata/src/required_keys/allowlist.txt",
+	}
+	for name, value := range flags {
+		if err := zconfigcheck.Analyzer.Flags.Set(name, value); err != nil {
+			t.Fatalf("Failed to set flag: %s", err)
+		}
+		defer zconfigcheck.Analyzer.Flags.Set(name, "")
+	}
+
+	analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/required_keys")
+}
+
+func TestKeyNaming(t *testing.T) {
+	testdata := testdata(t)
+
+	if err := zconfigcheck.Analyzer.Flags.Set("key-case", "pascal"); err == nil {
+		t.Errorf("Expected an error for an unknown case")
+	}
+	if err := zconfigcheck.Analyzer.Flags.Set("key-chars", "z-a"); err == nil {
+		t.Errorf("Expected an error for invalid characters")
+	}
+
+	flags := map[string]string{
+		"key-case":               "kebab",
+		"key-chars":              "a-zA-Z0-9-",
+		"key-max-depth":          "2",
+		"key-forbidden-prefixes": "zconfig",
+	}
+	for name, value := range flags {
+		if err := zconfigcheck.Analyzer.Flags.Set(name, value); err != nil {
+			t.Fatalf("Failed to set flag: %s", err)
+		}
+	}
+	defer func() {
+		for name := range flags {
+			_ = zconfigcheck.Analyzer.Flags.Set(name, "")
+		}
+		_ = zconfigcheck.Analyzer.Flags.Set("key-max-depth", "0")
+	}()
+
+	analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/naming")
+}
+
+func TestDisabledChecks(t *testing.T) {
+	testdata := testdata(t)
+
+	if err := zconfigcheck.Analyzer.Flags.Set("disable", "env-collision, ZC305"); err != nil {
+		t.Fatalf("Failed to set flag: %s", err)
+	}
+	defer zconfigcheck.Analyzer.Flags.Set("disable", "")
+
+	if err := zconfigcheck.Analyzer.Flags.Set("disable", "unknown-check"); err == nil {
+		t.Errorf("Expected an error for an unknown check")
+	}
+
+	analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/disabled")
+}
+
+func TestDiagnosticCategories(t *testing.T) {
+	testdata := testdata(t)
+
+	for _, result := range analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/tags") {
+		for _, diagnostic := range result.Diagnostics {
+			check := zconfigcheck.Check(diagnostic.Category)
+			if check.Code() == "" {
+				t.Errorf("Unexpected category %q for diagnostic %q", diagnostic.Category, diagnostic.Message)
+				continue
+			}
+
+			if !strings.HasPrefix(diagnostic.Message, check.Code()+": ") {
+				t.Errorf("Expected diagnostic %q to start with code %s", diagnostic.Message, check.Code())
+			}
+		}
+	}
+}
+
+func TestDiagnosticsOrder(t *testing.T) {
+	testdata := testdata(t)
+
+	expected := []string{
+		"4: ZC004: default tag is used on field without key tag",
+		"5: ZC002: description tag cannot be empty",
+		"5: ZC002: key tag cannot be empty",
+		"5: ZC004: default tag is used on field without key tag",
+		"6: ZC104: default value 'eighty' cannot be parsed as int: expected an integer between -9223372036854775808 and 9223372036854775807",
+	}
+
+	var got []string
+	for _, result := range analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/order") {
+		for _, diagnostic := range result.Diagnostics {
+			got = append(got, fmt.Sprintf("%d: %s", result.Pass.Fset.Position(diagnostic.Pos).Line, diagnostic.Message))
+		}
+	}
+
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected diagnostics order:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestRelatedInformation(t *testing.T) {
+	testdata := testdata(t)
+
+	expected := map[string]string{
+		"ZC201: no source is provided for alias 'databse' used by target fields: Target, did you mean 'database'?":                   "alias 'database' is provided by source field Database",
+		"ZC106: key 'timout' defined by field 'Timout' is similar to key 'timeout' used by field 'Timeout', did you mean 'timeout'?": "key 'timeout' is used by field Timeout",
+	}
+
+	for _, result := range analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/suggestions") {
+		for _, diagnostic := range result.Diagnostics {
+			related, ok := expected[diagnostic.Message]
+			if !ok {
+				continue
+			}
+			delete(expected, diagnostic.Message)
+
+			if len(diagnostic.Related) == 0 || diagnostic.Related[0].Message != related {
+				t.Errorf("Expected diagnostic %q to have related information %q, got %v", diagnostic.Message, related, diagnostic.Related)
+			}
+		}
+	}
+
+	for message := range expected {
+		t.Errorf("Expected diagnostic %q", message)
+	}
+}
+
+func TestIssueOrigins(t *testing.T) {
+	testdata := testdata(t)
+
+	expected := map[string]string{
+		"ZC201: no source is provided for alias 'db' used by target fields: DB":                                                               "repository.go:5",
+		"ZC203: injection alias 'value': cannot inject source field 'Source *bool' into target field 'Target *string', mismatched types":      "repository.go:10",
+		"ZC203: injection alias 'value': target field 'Target *string' cannot be injected with source field 'Source *bool', mismatched types": "repository.go:11",
+		"ZC205: no target is injected with alias 'source' provided by source fields: Source":                                                  "origins.go:13",
+	}
+
+	for _, result := range analysistest.Run(t, testdata, zconfigcheck.Analyzer, "testdata/src/origins") {
+		for _, diagnostic := range result.Diagnostics {
+			origin, ok := expected[diagnostic.Message]
+			if !ok {
+				continue
+			}
+			delete(expected, diagnostic.Message)
+
+			if len(diagnostic.Related) != 1 || diagnostic.Related[0].Message != "issue detected here" {
+				t.Errorf("Expected diagnostic %q to have an origin, got %v", diagnostic.Message, diagnostic.Related)
+				continue
+			}
+
+			position := result.Pass.Fset.Position(diagnostic.Related[0].Pos)
+			if got := fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line); got != origin {
+				t.Errorf("Expected diagnostic %q to originate from %s, got %s", diagnostic.Message, origin, got)
+			}
+
+			if end := result.Pass.Fset.Position(diagnostic.Related[0].End); end.Line != position.Line || end.Column <= position.Column {
+				t.Errorf("Expected the origin of diagnostic %q to end after %s, got %s", diagnostic.Message, position, end)
+			}
+		}
+	}
+
+	for message := range expected {
+		t.Errorf("Expected diagnostic %q", message)
+	}
+}
@@ -0,0 +1,191 @@
+package zconfigcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Check identifies a family of issues detected by zconfigcheck.
+// Check names are stable and are used to enable or disable checks.
+type Check string
+
+const (
+	CheckTagSyntax   Check = "tag-syntax"
+	CheckEmptyTag    Check = "empty-tag"
+	CheckPrivateTag  Check = "private-tag"
+	CheckOrphanTag   Check = "orphan-tag"
+	CheckTagConflict Check = "tag-conflict"
+	CheckKeySyntax   Check = "key-syntax"
+
+	CheckDefaultValue   Check = "default-value"
+	CheckUnparsableType Check = "unparsable-type"
+	CheckSimilarKey     Check = "similar-key"
+	CheckRequiredKey    Check = "required-key"
+	CheckKeyNaming      Check = "key-naming"
+
+	CheckDuplicateKey Check = "duplicate-key"
+	CheckEnvCollision Check = "env-collision"
+	CheckMissingKey   Check = "missing-key"
+
+	CheckUnresolvedAlias   Check = "unresolved-alias"
+	CheckDuplicateSource   Check = "duplicate-source"
+	CheckInjectionMismatch Check = "injection-mismatch"
+	CheckInjectionType     Check = "injection-type"
+	CheckUnusedSource      Check = "unused-source"
+
+	CheckInitReceiver   Check = "init-receiver"
+	CheckInitNotCalled  Check = "init-not-called"
+	CheckInitMultiCall  Check = "init-multi-call"
+	CheckInitNested     Check = "init-nested"
+	CheckInitDoubleCall Check = "init-double-call"
+
+	CheckDependencyCycle   Check = "dependency-cycle"
+	CheckConfigureArg      Check = "configure-arg"
+	CheckIgnoredError      Check = "ignored-error"
+	CheckMultipleConfigure Check = "multiple-configure"
+
+	CheckInvalidIgnore Check = "invalid-ignore"
+	CheckUnusedIgnore  Check = "unused-ignore"
+)
+
+// checkInfo contains the documentation of a check
+type checkInfo struct {
+	// Code is a stable identifier of the check, displayed in reported diagnostics
+	Code string
+	Doc  string
+}
+
+// checks contains the documentation of all known checks.
+// Codes are grouped by hundreds: tags (0xx), keys and values (1xx), injection (2xx),
+// Init methods (3xx), struct dependencies (4xx), zconfig calls (5xx) and directives (9xx).
+var checks = map[Check]checkInfo{
+	CheckTagSyntax:   {"ZC001", "struct tags cannot be parsed"},
+	CheckEmptyTag:    {"ZC002", "key, description, inject or inject-as tag is empty"},
+	CheckPrivateTag:  {"ZC003", "private field has tags"},
+	CheckOrphanTag:   {"ZC004", "default or description tag is used on a field without key tag"},
+	CheckTagConflict: {"ZC005", "field has incompatible tags"},
+	CheckKeySyntax:   {"ZC006", "key cannot be looked up by zconfig providers or has misplaced dots"},
+
+	CheckDuplicateKey:   {"ZC101", "key is used by more than one field"},
+	CheckEnvCollision:   {"ZC102", "different keys have the same environment variable name"},
+	CheckMissingKey:     {"ZC103", "field contains key tags but is not tagged with a key"},
+	CheckDefaultValue:   {"ZC104", "default tag value cannot be parsed for the field type"},
+	CheckUnparsableType: {"ZC105", "field type is not handled by zconfig default parsers"},
+	CheckSimilarKey:     {"ZC106", "key differs from another key only by case, separators or a typo"},
+	CheckRequiredKey:    {"ZC107", "key has no default value and must be provided (opt-in)"},
+	CheckKeyNaming:      {"ZC108", "key does not follow the naming convention set by the key options"},
+
+	CheckUnresolvedAlias:   {"ZC201", "no source is provided for an injection target"},
+	CheckDuplicateSource:   {"ZC202", "inject-as alias is used by more than one field"},
+	CheckInjectionMismatch: {"ZC203", "injection source and target types are incompatible"},
+	CheckInjectionType:     {"ZC204", "field type cannot be used as injection source or target"},
+	CheckUnusedSource:      {"ZC205", "injection source is not used by any target"},
+
+	CheckInitReceiver:   {"ZC301", "Init method is not declared on a pointer receiver"},
+	CheckInitNotCalled:  {"ZC302", "Init method won't be called by zconfig"},
+	CheckInitMultiCall:  {"ZC303", "Init method will be called more than once by zconfig"},
+	CheckInitNested:     {"ZC304", "struct field type has issues with its Init methods"},
+	CheckInitDoubleCall: {"ZC305", "Init method already invoked by zconfig is called explicitly"},
+
+	CheckDependencyCycle: {"ZC401", "configured struct contains a dependency cycle"},
+
+	CheckConfigureArg:      {"ZC501", "argument used as configuration receiver is not a struct pointer"},
+	CheckIgnoredError:      {"ZC502", "error returned by zconfig or one of its wrappers is not checked"},
+	CheckMultipleConfigure: {"ZC503", "struct is configured by more than one call"},
+
+	CheckInvalidIgnore: {"ZC901", "zconfigcheck:ignore directive is malformed"},
+	CheckUnusedIgnore:  {"ZC902", "zconfigcheck:ignore directive does not silence any issue"},
+}
+
+// optInChecks lists the checks which are only run when they are enabled by the enable option
+var optInChecks = checkSet{
+	CheckRequiredKey: {},
+}
+
+// Enabled returns true if the issues of the check are reported, according to the enable and disable options
+func (c Check) Enabled() bool {
+	if disabledChecks.Has(c) {
+		return false
+	}
+	return !optInChecks.Has(c) || enabledChecks.Has(c)
+}
+
+// Code returns the stable code of the check
+func (c Check) Code() string {
+	return checks[c].Code
+}
+
+// Doc returns the description of the check
+func (c Check) Doc() string {
+	return checks[c].Doc
+}
+
+// Checks returns all known checks, sorted by code
+func Checks() []Check {
+	all := make([]Check, 0, len(checks))
+	for check := range checks {
+		all = append(all, check)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Code() < all[j].Code() })
+	return all
+}
+
+// lookupCheck returns the check matching the given name or code
+func lookupCheck(nameOrCode string) (Check, bool) {
+	if _, ok := checks[Check(nameOrCode)]; ok {
+		return Check(nameOrCode), true
+	}
+
+	for check, info := range checks {
+		if info.Code == nameOrCode {
+			return check, true
+		}
+	}
+	return "", false
+}
+
+// checkNames returns the sorted list of all known check names
+func checkNames() []string {
+	names := make([]string, 0, len(checks))
+	for check := range checks {
+		names = append(names, string(check))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkSet is a flag.Value holding a comma-separated list of check names or codes
+type checkSet map[Check]struct{}
+
+func (s *checkSet) String() string {
+	names := make([]string, 0, len(*s))
+	for check := range *s {
+		names = append(names, string(check))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func (s *checkSet) Set(value string) error {
+	set := make(checkSet)
+
+	var list stringList
+	_ = list.Set(value)
+	for _, name := range list {
+		check, ok := lookupCheck(name)
+		if !ok {
+			return fmt.Errorf("unknown check %s, must be one of: %s", name, strings.Join(checkNames(), ", "))
+		}
+		set[check] = struct{}{}
+	}
+
+	*s = set
+	return nil
+}
+
+// Has returns true if the given check belongs to the set
+func (s checkSet) Has(check Check) bool {
+	_, ok := s[check]
+	return ok
+}
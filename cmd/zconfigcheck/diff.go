@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+const diffUsage = `Usage: zconfigcheck diff [-packages patterns] <old> <new>
+
+Diff compares the keys read by the structs configured by zconfig between two git revisions.
+It lists removed or renamed keys, type changes, changed defaults and new required keys.
+Changes which can break existing deployments are marked as breaking, and make the command
+exit with status 3.
+
+`
+
+// schemaChange is a difference between two versions of a configuration schema
+type schemaChange struct {
+	Root     string
+	Message  string
+	Breaking bool
+}
+
+func runDiff(args []string) error {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	packages := flags.String("packages", "./...", "comma-separated list of packages configuring structs")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), diffUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return errors.New("expected two revisions")
+	}
+
+	var snapshots [2][]zconfigcheck.Schema
+	for i, revision := range flags.Args() {
+		schemas, err := loadRevisionSchemas(revision, strings.Split(*packages, ","))
+		if err != nil {
+			return fmt.Errorf("revision %s: %w", revision, err)
+		}
+		snapshots[i] = schemas
+	}
+
+	changes := diffSchemas(snapshots[0], snapshots[1])
+	printChanges(os.Stdout, changes)
+	for _, change := range changes {
+		if change.Breaking {
+			return errIssues
+		}
+	}
+	return nil
+}
+
+// loadRevisionSchemas returns the schemas of the configuration roots of the given packages at a git revision.
+// The revision is checked out in a temporary worktree.
+func loadRevisionSchemas(revision string, patterns []string) ([]zconfigcheck.Schema, error) {
+	// packages are relative to the current directory, which may be a subdirectory of the repository
+	prefix, err := git("rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "zconfigcheck-diff")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	worktree := filepath.Join(tmp, "worktree")
+	if _, err := git("worktree", "add", "--detach", worktree, revision); err != nil {
+		return nil, err
+	}
+	defer git("worktree", "remove", "--force", worktree)
+
+	return loadSchemas(filepath.Join(worktree, prefix), patterns)
+}
+
+// git runs a git command in the current directory and returns its trimmed output
+func git(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// diffSchemas returns the changes between the old and new versions of configuration schemas.
+// Schemas are matched by root, and keys are matched by key or, when a key is renamed, by field path.
+func diffSchemas(oldSchemas, newSchemas []zconfigcheck.Schema) []schemaChange {
+	newRoots := make(map[string]zconfigcheck.Schema)
+	for _, schema := range newSchemas {
+		newRoots[schema.Root] = schema
+	}
+
+	var changes []schemaChange
+	oldRoots := make(map[string]bool)
+	for _, oldSchema := range oldSchemas {
+		oldRoots[oldSchema.Root] = true
+
+		newSchema, ok := newRoots[oldSchema.Root]
+		if !ok {
+			changes = append(changes, schemaChange{oldSchema.Root, "configured struct removed", true})
+			continue
+		}
+		changes = append(changes, diffKeys(oldSchema, newSchema)...)
+	}
+
+	for _, newSchema := range newSchemas {
+		if oldRoots[newSchema.Root] {
+			continue
+		}
+
+		changes = append(changes, schemaChange{newSchema.Root, "configured struct added", false})
+		for _, key := range newSchema.Keys {
+			if key.Required {
+				changes = append(changes, schemaChange{newSchema.Root, fmt.Sprintf("new required key %s (env %s)", key.Key, key.Env), true})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffKeys returns the changes between the keys of two versions of a schema
+func diffKeys(oldSchema, newSchema zconfigcheck.Schema) []schemaChange {
+	root := oldSchema.Root
+	change := func(breaking bool, format string, args ...any) schemaChange {
+		return schemaChange{root, fmt.Sprintf(format, args...), breaking}
+	}
+
+	oldKeys := make(map[string]zconfigcheck.SchemaKey)
+	for _, key := range oldSchema.Keys {
+		oldKeys[key.Key] = key
+	}
+
+	newKeys := make(map[string]zconfigcheck.SchemaKey)
+	newPaths := make(map[string]zconfigcheck.SchemaKey)
+	for _, key := range newSchema.Keys {
+		newKeys[key.Key] = key
+		newPaths[key.Path] = key
+	}
+
+	var changes []schemaChange
+	matched := make(map[string]bool)
+	for _, oldKey := range oldSchema.Keys {
+		newKey, ok := newKeys[oldKey.Key]
+		if !ok {
+			newKey, ok = newPaths[oldKey.Path]
+			if !ok || oldKeys[newKey.Key].Key != "" {
+				changes = append(changes, change(true, "removed key %s (env %s)", oldKey.Key, oldKey.Env))
+				continue
+			}
+
+			changes = append(changes, change(true, "key %s renamed to %s (env %s renamed to %s)",
+				oldKey.Key, newKey.Key, oldKey.Env, newKey.Env))
+		}
+		matched[newKey.Key] = true
+
+		if oldKey.Type != newKey.Type {
+			changes = append(changes, change(true, "key %s type changed from %s to %s", newKey.Key, oldKey.Type, newKey.Type))
+		}
+
+		switch {
+		case oldKey.Default != nil && newKey.Default == nil:
+			changes = append(changes, change(true, "key %s default '%s' removed, the key is now required", newKey.Key, *oldKey.Default))
+		case oldKey.Default == nil && newKey.Default != nil:
+			changes = append(changes, change(false, "key %s default '%s' added, the key is now optional", newKey.Key, *newKey.Default))
+		case oldKey.Default != nil && *oldKey.Default != *newKey.Default:
+			changes = append(changes, change(false, "key %s default changed from '%s' to '%s'", newKey.Key, *oldKey.Default, *newKey.Default))
+		}
+	}
+
+	for _, newKey := range newSchema.Keys {
+		if matched[newKey.Key] {
+			continue
+		}
+
+		if newKey.Required {
+			changes = append(changes, change(true, "new required key %s (env %s)", newKey.Key, newKey.Env))
+		} else {
+			changes = append(changes, change(false, "new key %s (env %s)", newKey.Key, newKey.Env))
+		}
+	}
+
+	return changes
+}
+
+// printChanges writes the given changes grouped by root
+func printChanges(w io.Writer, changes []schemaChange) {
+	var root string
+	for _, change := range changes {
+		if change.Root != root {
+			root = change.Root
+			fmt.Fprintf(w, "%s:\n", root)
+		}
+
+		if change.Breaking {
+			fmt.Fprintf(w, "  breaking: %s\n", change.Message)
+		} else {
+			fmt.Fprintf(w, "  %s\n", change.Message)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+func TestDiffSchemas(t *testing.T) {
+	fiveSeconds, tenSeconds, port := "5s", "10s", "5432"
+	oldSchemas := []zconfigcheck.Schema{
+		{
+			Root: "example.com/app.Config",
+			Keys: []zconfigcheck.SchemaKey{
+				{Key: "database.host", Env: "DATABASE_HOST", Path: "Database.Host", Type: "string", Required: true},
+				{Key: "database.port", Env: "DATABASE_PORT", Path: "Database.Port", Type: "int", Default: &port},
+				{Key: "db-user", Env: "DB_USER", Path: "Database.User", Type: "string", Required: true},
+				{Key: "debug", Env: "DEBUG", Path: "Debug", Type: "bool", Required: true},
+				{Key: "retries", Env: "RETRIES", Path: "Retries", Type: "int", Default: &port},
+				{Key: "timeout", Env: "TIMEOUT", Path: "Timeout", Type: "time.Duration", Default: &fiveSeconds},
+			},
+		},
+		{Root: "example.com/app.Removed"},
+	}
+	newSchemas := []zconfigcheck.Schema{
+		{
+			Root: "example.com/app.Config",
+			Keys: []zconfigcheck.SchemaKey{
+				{Key: "database.host", Env: "DATABASE_HOST", Path: "Database.Host", Type: "string", Default: &fiveSeconds},
+				{Key: "database.port", Env: "DATABASE_PORT", Path: "Database.Port", Type: "uint16", Default: &port},
+				{Key: "database.user", Env: "DATABASE_USER", Path: "Database.User", Type: "string", Required: true},
+				{Key: "name", Env: "NAME", Path: "Name", Type: "string", Required: true},
+				{Key: "retries", Env: "RETRIES", Path: "Retries", Type: "int", Required: true},
+				{Key: "timeout", Env: "TIMEOUT", Path: "Timeout", Type: "time.Duration", Default: &tenSeconds},
+				{Key: "verbose", Env: "VERBOSE", Path: "Verbose", Type: "bool", Default: &fiveSeconds},
+			},
+		},
+		{
+			Root: "example.com/app.Added",
+			Keys: []zconfigcheck.SchemaKey{{Key: "token", Env: "TOKEN", Path: "Token", Type: "string", Required: true}},
+		},
+	}
+
+	var b strings.Builder
+	changes := diffSchemas(oldSchemas, newSchemas)
+	printChanges(&b, changes)
+
+	expected := []string{
+		"example.com/app.Config:",
+		"  key database.host default '5s' added, the key is now optional",
+		"  breaking: key database.port type changed from int to uint16",
+		"  breaking: key db-user renamed to database.user (env DB_USER renamed to DATABASE_USER)",
+		"  breaking: removed key debug (env DEBUG)",
+		"  breaking: key retries default '5432' removed, the key is now required",
+		"  key timeout default changed from '5s' to '10s'",
+		"  breaking: new required key name (env NAME)",
+		"  new key verbose (env VERBOSE)",
+		"example.com/app.Removed:",
+		"  breaking: configured struct removed",
+		"example.com/app.Added:",
+		"  configured struct added",
+		"  breaking: new required key token (env TOKEN)",
+		"",
+	}
+	if lines := strings.Split(b.String(), "\n"); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Unexpected changes:\n%s", b.String())
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+const docUsage = `Usage: zconfigcheck doc [-o file] [packages]
+
+Doc renders a Markdown reference of the keys read by each struct configured by zconfig
+in the given packages.
+
+`
+
+func runDoc(args []string) error {
+	flags := flag.NewFlagSet("doc", flag.ExitOnError)
+	output := flags.String("o", "", "file where the documentation is written, instead of the standard output")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), docUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	schemas, err := loadSchemas("", patterns)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return renderDoc(w, schemas)
+}
+
+// renderDoc writes the Markdown documentation of the given schemas.
+// Each schema keys are rendered as tables, grouped by the path of the struct declaring them.
+func renderDoc(w io.Writer, schemas []zconfigcheck.Schema) error {
+	var b strings.Builder
+	b.WriteString("# Configuration reference\n")
+
+	for _, schema := range schemas {
+		fmt.Fprintf(&b, "\n## %s\n", markdownCell(schema.Root))
+		if len(schema.Keys) == 0 {
+			b.WriteString("\nNo configuration key.\n")
+			continue
+		}
+
+		var groups []string
+		keys := make(map[string][]zconfigcheck.SchemaKey)
+		for _, key := range schema.Keys {
+			group := ""
+			if i := strings.LastIndex(key.Path, "."); i != -1 {
+				group = key.Path[:i]
+			}
+
+			if _, ok := keys[group]; !ok {
+				groups = append(groups, group)
+			}
+			keys[group] = append(keys[group], key)
+		}
+
+		// keys of the root struct are rendered first
+		sort.Strings(groups)
+		for _, group := range groups {
+			if group != "" {
+				fmt.Fprintf(&b, "\n### %s\n", markdownCell(group))
+			}
+
+			b.WriteString("\n| Key | Env | Type | Default | Description |\n")
+			b.WriteString("|-----|-----|------|---------|-------------|\n")
+			for _, key := range keys[group] {
+				def := "*required*"
+				if key.Default != nil && *key.Default == "" {
+					def = "*empty*"
+				} else if key.Default != nil {
+					def = "`" + markdownCell(*key.Default) + "`"
+				}
+
+				fmt.Fprintf(&b, "| `%s` | `%s` | `%s` | %s | %s |\n",
+					markdownCell(key.Key), markdownCell(key.Env), markdownCell(key.Type), def, markdownCell(key.Description))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell escapes the given value so that it can be used in a Markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+func TestRenderDoc(t *testing.T) {
+	def, empty := "5s", ""
+	schemas := []zconfigcheck.Schema{
+		{
+			Root: "example.com/app.Config",
+			Keys: []zconfigcheck.SchemaKey{
+				{Key: "database.host", Env: "DATABASE_HOST", Path: "Database.Host", Type: "string", Description: "host | port", Required: true},
+				{Key: "database.password", Env: "DATABASE_PASSWORD", Path: "Database.Password", Type: "string", Default: &empty},
+				{Key: "timeout", Env: "TIMEOUT", Path: "Timeout", Type: "time.Duration", Default: &def},
+			},
+		},
+		{Root: "example.com/app/main.go:12"},
+	}
+
+	var b strings.Builder
+	if err := renderDoc(&b, schemas); err != nil {
+		t.Fatalf("Failed to render doc: %s", err)
+	}
+
+	want, err := os.ReadFile("testdata/doc.md")
+	if err != nil {
+		t.Fatalf("Failed to read expected doc: %s", err)
+	}
+
+	if b.String() != string(want) {
+		t.Errorf("Unexpected doc:\n%s", b.String())
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/synthesio/zconfigcheck"
+	"gopkg.in/yaml.v3"
+)
+
+const lintUsage = `Usage: zconfigcheck lint [-root type] [-packages patterns] files...
+
+Lint checks configuration files against the keys read by a struct configured by zconfig.
+It reports unknown keys, values which cannot be parsed for the field type and required keys
+which are provided by none of the files.
+
+Files are read according to their extension:
+  .env             dotenv files, using environment variable names
+  .yaml .yml .json nested objects, whose keys are joined with dots
+  .args .flags     command line arguments, such as --db.host=localhost
+
+`
+
+// errIssues is returned by commands which reported issues
+var errIssues = errors.New("issues found")
+
+// configValue is a value set for a key by a configuration file
+type configValue struct {
+	// Key is either a zconfig key or an environment variable name
+	Key   string
+	Value string
+	Line  int
+}
+
+// lintIssue is an issue detected in a configuration file
+type lintIssue struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (i lintIssue) String() string {
+	if i.File == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+}
+
+func runLint(args []string) error {
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	root := flags.String("root", "", "configured struct type whose keys are checked, required when packages configure several structs")
+	packages := flags.String("packages", "./...", "comma-separated list of packages configuring the struct")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), lintUsage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errors.New("no configuration file")
+	}
+
+	schemas, err := loadSchemas("", strings.Split(*packages, ","))
+	if err != nil {
+		return err
+	}
+
+	schema, err := selectSchema(schemas, *root)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[string][]configValue)
+	for _, file := range flags.Args() {
+		values, err := readConfigFile(file)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", file, err)
+		}
+		files[file] = values
+	}
+
+	issues := lintFiles(schema, files)
+	for _, issue := range issues {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+	if len(issues) > 0 {
+		return errIssues
+	}
+	return nil
+}
+
+// selectSchema returns the schema of the given root, which can be omitted when there is a single schema.
+// Roots can be designated by their full name or by their name without the package path, e.g. app.Config.
+func selectSchema(schemas []zconfigcheck.Schema, root string) (zconfigcheck.Schema, error) {
+	var roots []string
+	for _, schema := range schemas {
+		if root == "" && len(schemas) == 1 || schema.Root == root || strings.HasSuffix(schema.Root, "/"+root) {
+			return schema, nil
+		}
+		roots = append(roots, schema.Root)
+	}
+
+	if len(roots) == 0 {
+		return zconfigcheck.Schema{}, errors.New("no configured struct found")
+	}
+	return zconfigcheck.Schema{}, fmt.Errorf("use -root to select a configured struct amongst: %s", strings.Join(roots, ", "))
+}
+
+// lintFiles returns the issues detected in the given configuration files, whose values are indexed by file name.
+// Required keys are reported when they are missing from all files.
+func lintFiles(schema zconfigcheck.Schema, files map[string][]configValue) []lintIssue {
+	keys := make(map[string]zconfigcheck.SchemaKey)
+	envs := make(map[string]zconfigcheck.SchemaKey)
+	for _, key := range schema.Keys {
+		keys[key.Key] = key
+		envs[key.Env] = key
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []lintIssue
+	provided := make(map[string]bool)
+	for _, name := range names {
+		isEnv := filepath.Ext(name) == ".env"
+		for _, value := range files[name] {
+			key, ok := keys[value.Key]
+			if isEnv {
+				key, ok = envs[value.Key]
+			}
+			if !ok {
+				issues = append(issues, lintIssue{name, value.Line, fmt.Sprintf("unknown key %s", value.Key)})
+				continue
+			}
+
+			provided[key.Key] = true
+			if err := zconfigcheck.CheckValue(key.Type, value.Value); err != nil {
+				issues = append(issues, lintIssue{name, value.Line, fmt.Sprintf(
+					"value '%s' of key %s cannot be parsed as %s: %s", value.Value, value.Key, key.Type, err)})
+			}
+		}
+	}
+
+	for _, key := range schema.Keys {
+		if key.Required && !provided[key.Key] {
+			issues = append(issues, lintIssue{Message: fmt.Sprintf(
+				"required key %s (env %s) is not provided by any file", key.Key, key.Env)})
+		}
+	}
+
+	return issues
+}
+
+// readConfigFile returns the values set by the given configuration file, according to its extension
+func readConfigFile(name string) ([]configValue, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch filepath.Ext(name) {
+	case ".env":
+		return readDotenv(string(content))
+	case ".yaml", ".yml", ".json":
+		return readYAML(content)
+	case ".args", ".flags":
+		return readArgs(string(content)), nil
+	}
+	return nil, fmt.Errorf("unsupported file extension %s", filepath.Ext(name))
+}
+
+// readDotenv returns the values of a dotenv file, whose keys are environment variable names
+func readDotenv(content string) ([]configValue, error) {
+	var values []configValue
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", line)
+		}
+
+		value = strings.TrimSpace(value)
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) > 1:
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i != -1 {
+				value = strings.TrimSpace(value[:i])
+			}
+		}
+
+		values = append(values, configValue{Key: strings.TrimSpace(key), Value: value, Line: line})
+	}
+	return values, scanner.Err()
+}
+
+// readYAML returns the values of a YAML or JSON document. Keys of nested objects are joined with dots,
+// and lists are joined with commas as expected by zconfig parsers.
+func readYAML(content []byte) ([]configValue, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	var values []configValue
+	var walk func(prefix string, node *yaml.Node) error
+	walk = func(prefix string, node *yaml.Node) error {
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				if prefix != "" {
+					key = prefix + "." + key
+				}
+				if err := walk(key, node.Content[i+1]); err != nil {
+					return err
+				}
+			}
+		case yaml.SequenceNode:
+			items := make([]string, 0, len(node.Content))
+			for _, item := range node.Content {
+				if item.Kind != yaml.ScalarNode {
+					return fmt.Errorf("line %d: lists of key %s must only contain scalar values", item.Line, prefix)
+				}
+				items = append(items, item.Value)
+			}
+			values = append(values, configValue{Key: prefix, Value: strings.Join(items, ","), Line: node.Line})
+		case yaml.ScalarNode:
+			if prefix == "" {
+				return fmt.Errorf("line %d: expected an object", node.Line)
+			}
+			values = append(values, configValue{Key: prefix, Value: node.Value, Line: node.Line})
+		case yaml.AliasNode:
+			return walk(prefix, node.Alias)
+		}
+		return nil
+	}
+
+	return values, walk("", doc.Content[0])
+}
+
+// readArgs returns the values of a list of command line arguments, parsed as the zconfig args provider does
+func readArgs(content string) []configValue {
+	type arg struct {
+		text string
+		line int
+	}
+
+	var args []arg
+	for i, line := range strings.Split(content, "\n") {
+		for _, text := range strings.Fields(line) {
+			args = append(args, arg{text, i + 1})
+		}
+	}
+
+	var values []configValue
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i].text, "--") {
+			continue
+		}
+
+		line := args[i].line
+		key, value, ok := strings.Cut(strings.TrimPrefix(args[i].text, "--"), "=")
+		if !ok && i+1 < len(args) && !strings.HasPrefix(args[i+1].text, "--") {
+			value = args[i+1].text
+			i++
+		}
+		values = append(values, configValue{Key: key, Value: value, Line: line})
+	}
+	return values
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+func TestLintFiles(t *testing.T) {
+	def := "5s"
+	schema := zconfigcheck.Schema{
+		Root: "example.com/app.Config",
+		Keys: []zconfigcheck.SchemaKey{
+			{Key: "database.host", Env: "DATABASE_HOST", Type: "string", Required: true},
+			{Key: "database.port", Env: "DATABASE_PORT", Type: "int", Required: true},
+			{Key: "database.user", Env: "DATABASE_USER", Type: "string", Required: true},
+			{Key: "ids", Env: "IDS", Type: "[]int64", Required: true},
+			{Key: "names", Env: "NAMES", Type: "[]string", Required: true},
+			{Key: "timeout", Env: "TIMEOUT", Type: "time.Duration", Default: &def},
+		},
+	}
+
+	files := make(map[string][]configValue)
+	for _, name := range []string{"config.env", "config.yaml", "config.json", "config.args"} {
+		values, err := readConfigFile(filepath.Join("testdata/lint", name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %s", name, err)
+		}
+		files[name] = values
+	}
+
+	var issues []string
+	for _, issue := range lintFiles(schema, files) {
+		issues = append(issues, issue.String())
+	}
+
+	expected := []string{
+		"config.args:2: unknown key verbose",
+		"config.env:3: value 'eighty' of key DATABASE_PORT cannot be parsed as int: expected an integer between -9223372036854775808 and 9223372036854775807",
+		"config.env:4: unknown key DATABSE_USER",
+		"config.json:5: unknown key unknown",
+		"config.yaml:1: value '5' of key timeout cannot be parsed as time.Duration: expected a duration with a unit suffix, such as '300ms' or '1h30m'",
+		"config.yaml:5: value '1,two' of key ids cannot be parsed as []int64: expected a comma-separated list of integers",
+		"required key database.user (env DATABASE_USER) is not provided by any file",
+	}
+	if !reflect.DeepEqual(issues, expected) {
+		t.Errorf("Unexpected issues:\n%q", issues)
+	}
+}
@@ -1,10 +1,53 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+
 	"github.com/synthesio/zconfigcheck"
-	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/analysis/multichecker"
 )
 
+// commands contains the subcommands of zconfigcheck, which are run instead of the analyzer
+// when their name is the first argument
+var commands = map[string]func(args []string) error{
+	"diff": runDiff,
+	"doc":  runDoc,
+	"lint": runLint,
+}
+
 func main() {
-	singlechecker.Main(zconfigcheck.Analyzer)
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			exit("zconfigcheck "+os.Args[1], command(os.Args[2:]))
+			return
+		}
+	}
+
+	format, args, err := parseFormat(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "zconfigcheck: %s\n", err)
+		os.Exit(1)
+	}
+	if format == sarifFormat {
+		exit("zconfigcheck", runSARIF(args))
+		return
+	}
+	os.Args = append(os.Args[:1], args...)
+
+	// multichecker prefixes the analyzer flags with its name, e.g. -zconfigcheck.disable
+	multichecker.Main(zconfigcheck.Analyzer)
+}
+
+// exit terminates the process with the status matching the error returned by a command,
+// which is printed with the given prefix
+func exit(prefix string, err error) {
+	if errors.Is(err, errIssues) {
+		os.Exit(exitDiagnostics)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", prefix, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+// formats of the analyzer output, set with the -format option
+const (
+	textFormat  = "text"
+	sarifFormat = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifSrcRoot = "%SRCROOT%"
+	checksURI    = "https://github.com/synthesio/zconfigcheck#checks"
+)
+
+// parseFormat returns the value of the -format option and the other arguments.
+// The option is handled by zconfigcheck itself, because the analyzer driver does not know it.
+func parseFormat(args []string) (string, []string, error) {
+	format := textFormat
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		name, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != "format" {
+			rest = append(rest, arg)
+			continue
+		}
+		if !ok {
+			if i+1 == len(args) {
+				return "", nil, errors.New("missing value of -format option")
+			}
+			i++
+			value = args[i]
+		}
+		format = value
+	}
+
+	if format != textFormat && format != sarifFormat {
+		return "", nil, fmt.Errorf("unknown format %q, expected %s or %s", format, textFormat, sarifFormat)
+	}
+	return format, rest, nil
+}
+
+// runSARIF writes the diagnostics of the analyzer in the SARIF format.
+// The analyzer runs in a child process with the -json option, whose output is converted.
+func runSARIF(args []string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(self, append([]string{"-json"}, args...)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	var tree jsonTree
+	if err := json.Unmarshal(stdout.Bytes(), &tree); err != nil {
+		return fmt.Errorf("reading analyzer output: %w", err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	log := sarifReport(tree, wd)
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return err
+	}
+
+	run := log.Runs[0]
+	if !run.Invocations[0].ExecutionSuccessful {
+		return errors.New("analysis failed")
+	}
+	if len(run.Results) > 0 {
+		return errIssues
+	}
+	return nil
+}
+
+// jsonTree is the output of the analyzer with the -json option. For each package and analyzer,
+// it contains either the list of diagnostics or the error which prevented the analysis.
+type jsonTree map[string]map[string]json.RawMessage
+
+type jsonDiagnostic struct {
+	Category string                   `json:"category"`
+	Posn     string                   `json:"posn"`
+	End      string                   `json:"end"`
+	Message  string                   `json:"message"`
+	Related  []jsonRelatedInformation `json:"related"`
+}
+
+type jsonRelatedInformation struct {
+	Posn    string `json:"posn"`
+	End     string `json:"end"`
+	Message string `json:"message"`
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                    `json:"tool"`
+	Invocations        []sarifInvocation            `json:"invocations"`
+	OriginalURIBaseIDs map[string]sarifArtifactLink `json:"originalUriBaseIds"`
+	Results            []sarifResult                `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	Help             sarifMessage `json:"help"`
+	HelpURI          string       `json:"helpUri"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifResult struct {
+	RuleID           string          `json:"ruleId"`
+	RuleIndex        int             `json:"ruleIndex"`
+	Level            string          `json:"level"`
+	Message          sarifMessage    `json:"message"`
+	Locations        []sarifLocation `json:"locations"`
+	RelatedLocations []sarifLocation `json:"relatedLocations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	ID               int                   `json:"id,omitempty"`
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	Message          *sarifMessage         `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLink `json:"artifactLocation"`
+	Region           sarifRegion       `json:"region"`
+}
+
+type sarifArtifactLink struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// sarifReport converts the output of the analyzer into a SARIF log. Files below the root directory
+// are referred to relatively to it. Diagnostics reported on both a package and its test variant
+// are only included once.
+func sarifReport(tree jsonTree, root string) sarifLog {
+	var rules []sarifRule
+	ruleIndices := make(map[string]int)
+	for _, check := range zconfigcheck.Checks() {
+		ruleIndices[string(check)] = len(rules)
+		rules = append(rules, sarifRule{
+			ID:               check.Code(),
+			Name:             string(check),
+			ShortDescription: sarifMessage{check.Doc()},
+			Help: sarifMessage{fmt.Sprintf("%s%s. The check can be disabled with the -%s.disable=%s option.",
+				strings.ToUpper(check.Doc()[:1]), check.Doc()[1:], zconfigcheck.LinterName, check)},
+			HelpURI: checksURI,
+		})
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+	results := make([]sarifResult, 0)
+	seen := make(map[string]bool)
+
+	pkgs := make([]string, 0, len(tree))
+	for pkg := range tree {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	for _, pkg := range pkgs {
+		raw, ok := tree[pkg][zconfigcheck.LinterName]
+		if !ok {
+			continue
+		}
+
+		var diagnostics []jsonDiagnostic
+		if err := json.Unmarshal(raw, &diagnostics); err != nil {
+			var failure struct {
+				Err string `json:"error"`
+			}
+			_ = json.Unmarshal(raw, &failure)
+
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:   "error",
+				Message: sarifMessage{fmt.Sprintf("%s: %s", pkg, failure.Err)},
+			})
+			continue
+		}
+
+		for _, diagnostic := range diagnostics {
+			key := diagnostic.Posn + "\x00" + diagnostic.Message
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			index, ok := ruleIndices[diagnostic.Category]
+			if !ok {
+				index = len(rules)
+				ruleIndices[diagnostic.Category] = index
+				rules = append(rules, sarifRule{ID: diagnostic.Category, Name: diagnostic.Category, HelpURI: checksURI})
+			}
+			rule := rules[index]
+
+			result := sarifResult{
+				RuleID:    rule.ID,
+				RuleIndex: index,
+				Level:     "warning",
+				Message:   sarifMessage{strings.TrimPrefix(diagnostic.Message, rule.ID+": ")},
+				Locations: []sarifLocation{{PhysicalLocation: physicalLocation(diagnostic.Posn, diagnostic.End, root)}},
+			}
+			for i, related := range diagnostic.Related {
+				result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+					ID:               i + 1,
+					PhysicalLocation: physicalLocation(related.Posn, related.End, root),
+					Message:          &sarifMessage{related.Message},
+				})
+			}
+			results = append(results, result)
+		}
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           zconfigcheck.LinterName,
+				InformationURI: "https://github.com/synthesio/zconfigcheck",
+				Rules:          rules,
+			}},
+			Invocations:        []sarifInvocation{invocation},
+			OriginalURIBaseIDs: map[string]sarifArtifactLink{sarifSrcRoot: {URI: fileURI(root) + "/"}},
+			Results:            results,
+		}},
+	}
+}
+
+// physicalLocation converts positions formatted as file:line:column into a SARIF location
+func physicalLocation(posn, end, root string) sarifPhysicalLocation {
+	file, line, column := splitPosn(posn)
+
+	artifact := sarifArtifactLink{URI: fileURI(file)}
+	if rel, err := filepath.Rel(root, file); err == nil && filepath.IsAbs(file) && !strings.HasPrefix(rel, "..") {
+		artifact = sarifArtifactLink{URI: filepath.ToSlash(rel), URIBaseID: sarifSrcRoot}
+	}
+
+	region := sarifRegion{StartLine: line, StartColumn: column}
+	if endFile, endLine, endColumn := splitPosn(end); endFile == file && endLine > 0 {
+		region.EndLine, region.EndColumn = endLine, endColumn
+	}
+
+	return sarifPhysicalLocation{ArtifactLocation: artifact, Region: region}
+}
+
+// splitPosn splits a position formatted as file:line:column or file:line.
+// The line and column are 0 when they are missing.
+func splitPosn(posn string) (string, int, int) {
+	file, line, column := posn, 0, 0
+	for _, n := range []*int{&column, &line} {
+		i := strings.LastIndex(file, ":")
+		if i < 0 {
+			break
+		}
+
+		value, err := strconv.Atoi(file[i+1:])
+		if err != nil {
+			break
+		}
+		*n, file = value, file[:i]
+	}
+
+	if line == 0 {
+		// the position only contains a line
+		line, column = column, 0
+	}
+	return file, line, column
+}
+
+// fileURI returns the file URI of the given path
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
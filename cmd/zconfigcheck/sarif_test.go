@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, test := range []struct {
+		args   []string
+		format string
+		rest   []string
+	}{
+		{[]string{"./..."}, textFormat, []string{"./..."}},
+		{[]string{"-format=sarif", "-zconfigcheck.disable=env-collision", "./..."}, sarifFormat, []string{"-zconfigcheck.disable=env-collision", "./..."}},
+		{[]string{"--format", "sarif", "./..."}, sarifFormat, []string{"./..."}},
+		{[]string{"-format", "text", "./..."}, textFormat, []string{"./..."}},
+	} {
+		format, rest, err := parseFormat(test.args)
+		if err != nil {
+			t.Errorf("Failed to parse %q: %s", test.args, err)
+			continue
+		}
+		if format != test.format || !reflect.DeepEqual(rest, test.rest) {
+			t.Errorf("Unexpected format %q and arguments %q for %q", format, rest, test.args)
+		}
+	}
+
+	for _, args := range [][]string{{"-format=xml", "./..."}, {"./...", "-format"}} {
+		if _, _, err := parseFormat(args); err == nil {
+			t.Errorf("Expected an error for %q", args)
+		}
+	}
+}
+
+// runMainEnv is set when the test binary runs the zconfigcheck command instead of the tests,
+// since the SARIF format runs the command itself in a child process
+const runMainEnv = "ZCONFIGCHECK_RUN_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runSARIFCommand runs the zconfigcheck command with the SARIF format on the testdata/sarif module
+// and returns its output
+func runSARIFCommand(t *testing.T, args ...string) (sarifLog, string, error) {
+	dir, err := filepath.Abs("testdata/sarif")
+	if err != nil {
+		t.Fatalf("Failed to get directory: %s", err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(os.Args[0], append([]string{"-format=sarif"}, append(args, "./...")...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), runMainEnv+"=1")
+	cmd.Stdout = &stdout
+	err = cmd.Run()
+
+	var log sarifLog
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("Failed to read output: %s\n%s", err, stdout.String())
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("Expected 1 run, got %d", len(log.Runs))
+	}
+	return log, dir, err
+}
+
+func TestSARIF(t *testing.T) {
+	log, dir, err := runSARIFCommand(t)
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != exitDiagnostics {
+		t.Fatalf("Expected exit code %d, got %v", exitDiagnostics, err)
+	}
+
+	run := log.Runs[0]
+
+	if run.OriginalURIBaseIDs[sarifSrcRoot].URI != fileURI(dir)+"/" {
+		t.Errorf("Unexpected source root %q", run.OriginalURIBaseIDs[sarifSrcRoot].URI)
+	}
+
+	if invocation := run.Invocations[0]; !invocation.ExecutionSuccessful || len(invocation.ToolExecutionNotifications) > 0 {
+		t.Errorf("Unexpected invocation %+v", invocation)
+	}
+
+	// the diagnostic is reported on both the package and its test variant, but only included once
+	if len(run.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(run.Results))
+	}
+
+	result := run.Results[0]
+	if rule := run.Tool.Driver.Rules[result.RuleIndex]; result.RuleID != "ZC201" || rule.ID != "ZC201" || rule.Name != "unresolved-alias" {
+		t.Errorf("Unexpected rule %s for result %+v", rule.ID, result)
+	}
+
+	if result.Message.Text != "no source is provided for alias 'db' used by target fields: DB" {
+		t.Errorf("Unexpected message %q", result.Message.Text)
+	}
+
+	// the call configuring the struct is highlighted
+	expected := sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLink{URI: "sarif.go", URIBaseID: sarifSrcRoot},
+		Region:           sarifRegion{StartLine: 14, StartColumn: 26, EndLine: 14, EndColumn: 48},
+	}
+	if !reflect.DeepEqual(result.Locations, []sarifLocation{{PhysicalLocation: expected}}) {
+		t.Errorf("Unexpected locations %+v", result.Locations)
+	}
+
+	// the declaration of the field where the issue is detected is highlighted
+	expected = sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLink{URI: "sarif.go", URIBaseID: sarifSrcRoot},
+		Region:           sarifRegion{StartLine: 10, StartColumn: 2, EndLine: 10, EndColumn: 26},
+	}
+	if len(result.RelatedLocations) != 1 || result.RelatedLocations[0].ID != 1 ||
+		!reflect.DeepEqual(result.RelatedLocations[0].PhysicalLocation, expected) ||
+		result.RelatedLocations[0].Message.Text != "issue detected here" {
+		t.Errorf("Unexpected related locations %+v", result.RelatedLocations)
+	}
+}
+
+func TestSARIFErrors(t *testing.T) {
+	// schemas cannot be written below a file
+	log, _, err := runSARIFCommand(t, "-zconfigcheck.schema=sarif.go/schemas")
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("Expected exit code 1, got %v", err)
+	}
+
+	// the analysis fails on the package, its test variant and the test main package depending on it
+	invocation := log.Runs[0].Invocations[0]
+	if invocation.ExecutionSuccessful || len(invocation.ToolExecutionNotifications) != 3 ||
+		invocation.ToolExecutionNotifications[0].Message.Text != "sarif: writing schema of sarif.Repository: mkdir sarif.go: not a directory" {
+		t.Errorf("Unexpected invocation %+v", invocation)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/synthesio/zconfigcheck"
+)
+
+// exitDiagnostics is the exit code of the analyzer when issues are reported
+const exitDiagnostics = 3
+
+// loadSchemas returns the schemas of all configuration roots of the given packages, sorted by root.
+// The analyzer runs in a child process, because it reads its options from the command line
+// and writes the schemas into a directory.
+func loadSchemas(dir string, patterns []string) ([]zconfigcheck.Schema, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaDir, err := os.MkdirTemp("", "zconfigcheck-schemas")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(schemaDir)
+
+	args := append([]string{"-test=false", "-zconfigcheck.schema=" + schemaDir}, patterns...)
+	cmd := exec.Command(self, args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); errors.As(err, &exitErr) && exitErr.ExitCode() == exitDiagnostics {
+		// issues reported with the configuration do not prevent extracting its schema
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+
+	files, err := filepath.Glob(filepath.Join(schemaDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]zconfigcheck.Schema, 0, len(files))
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var schema zconfigcheck.Schema
+		if err := json.Unmarshal(content, &schema); err != nil {
+			return nil, fmt.Errorf("reading schema %s: %w", filepath.Base(file), err)
+		}
+		schemas = append(schemas, schema)
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Root < schemas[j].Root
+	})
+	return schemas, nil
+}
@@ -0,0 +1,5 @@
+module sarif
+
+go 1.22.3
+
+require github.com/synthesio/zconfig/v2 v2.1.0
@@ -0,0 +1,15 @@
+package sarif
+
+import (
+	"context"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Repository struct {
+	DB *string `inject:"db"`
+}
+
+func Configure(ctx context.Context) error {
+	return zconfig.Configure(ctx, new(Repository))
+}
@@ -0,0 +1,11 @@
+package sarif
+
+import (
+	"context"
+	"testing"
+)
+
+// TestConfigure makes the package have a test variant, whose diagnostics are also reported by the analyzer
+func TestConfigure(t *testing.T) {
+	_ = Configure(context.Background())
+}
@@ -2,7 +2,10 @@ package zconfigcheck
 
 import (
 	"fmt"
+	"go/token"
 	"go/types"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -10,32 +13,55 @@ import (
 	"golang.org/x/tools/go/ssa"
 )
 
-// getArgIssues returns any issues arising when the given value is used as the argument for a call to zconfig.Configure
-func (c *checker) getArgIssues(arg ssa.Value) []string {
+// getArgFact returns the type and structFact of the struct used as configuration root when the given value is
+// the argument for a call to zconfig.Configure. If the argument is not a known struct pointer, then the fact is nil
+// and the reported issues explain why.
+func (c *checker) getArgFact(arg ssa.Value) (types.Type, *structFact, []Issue) {
 	typ := getStructType(arg)
 	if typ == nil {
-		return []string{"argument used as configuration receiver is not a struct pointer"}
+		return nil, nil, []Issue{newIssue(CheckConfigureArg, "argument used as configuration receiver is not a struct pointer")}
 	}
 
 	if info, ok := c.PkgStructs[typ]; ok {
-		// this struct was declared in this package, so we can directly access its issues
-		return info.Fact().Issues
+		// this struct was declared in this package, so we can directly access its information
+		return typ, c.structFact(info), nil
 	}
 
 	named, ok := typ.(*types.Named)
 	if !ok {
 		// this should never happen, because the language does not allow importing anonymous structs
 		// from other packages
-		return []string{"unexpected anonymous struct"}
+		return nil, nil, []Issue{newIssue(CheckConfigureArg, "unexpected anonymous struct")}
 	}
 
 	var fact structFact
 	if c.Pass.ImportObjectFact(named.Obj(), &fact) {
-		return fact.Issues
+		if named.TypeArgs().Len() > 0 {
+			fact.Issues = append(fact.Issues, instanceIssues(named, fact.Scope)...)
+		}
+		return typ, &fact, nil
 	}
 
 	// this should never happen
-	return []string{"cannot find any information about the struct"}
+	return nil, nil, []Issue{newIssue(CheckConfigureArg, "cannot find any information about the struct")}
+}
+
+// instanceIssues returns the injection issues of an instantiation of a generic struct. The fact of a generic struct
+// is computed on its declaration, where fields use type parameters, so its scope is checked again once resolved
+// with the actual type arguments.
+func instanceIssues(named *types.Named, scope scopeFact) []Issue {
+	var issues []Issue
+	positions := scope.Positions()
+	scopeIssues := scope.Resolve(named).Check()
+	for _, pos := range scopeIssues.Positions() {
+		for _, issue := range scopeIssues[pos] {
+			if issue.Check == CheckInjectionMismatch {
+				issue.Position = positions[pos]
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
 }
 
 // getStructType returns the *types.Struct matching the given value.
@@ -51,7 +77,7 @@ func getStructType(arg ssa.Value) types.Type {
 		return nil
 	}
 
-	elem := ptr.Elem()
+	elem := types.Unalias(ptr.Elem())
 	if _, ok := elem.Underlying().(*types.Struct); ok {
 		return elem
 	}
@@ -97,9 +123,9 @@ func (c *checker) scanPackage() bool {
 // as wrappers.
 // Any call to one of these wrappers will then be considered as a call to
 // zconfig.
-func (c *checker) detectCalls() {
+func (c *checker) detectCalls() error {
 	if !c.scanPackage() {
-		return
+		return nil
 	}
 
 	if strings.HasPrefix(c.Pass.Pkg.Path(), zconfigPkgName) {
@@ -107,12 +133,12 @@ func (c *checker) detectCalls() {
 		// the zconfig/Processor.Process method
 		processor := c.SSA.Pkg.Type("Processor")
 		if processor == nil {
-			return
+			return nil
 		}
 
 		fnObj, _, _ := types.LookupFieldOrMethod(processor.Type(), true, c.Pass.Pkg, "Process")
 
-		c.Pass.ExportObjectFact(fnObj, &wrapperFact{IsInvoke: true, ArgIndex: 2})
+		c.Pass.ExportObjectFact(fnObj, &wrapperFact{IsInvoke: true, ArgIndex: 2, ReturnsErr: true})
 		c.Pass.ExportPackageFact(new(hasWrappersFact))
 	}
 
@@ -121,60 +147,165 @@ func (c *checker) detectCalls() {
 		pass:     c.Pass,
 	}
 
-	graph := c.CallGraph()
-	for _, node := range graph.Nodes {
-		// Try to find a call path leading to one of the known wrappers
-		path := callgraph.PathSearch(node, func(node *callgraph.Node) bool {
-			_, ok := wrappers.get(node)
-			return ok
-		})
-		if len(path) == 0 {
-			continue
-		}
+	// call sites already checked, since a dynamic call site has an edge to each of its possible callees
+	checked := make(map[ssa.CallInstruction]bool)
 
-		if _, ok := path[0].Site.(*ssa.Call); !ok {
-			// If the path starts with anything other than a call, then it could
-			// be an anonymous function call, a call to defer or a goroutine.
-			// In these cases we know that there are longer paths which include this one
-			// as their "suffix", so we prefer processing those paths.
-			continue
-		}
+	// calls configuring each allocation or package variable
+	configured := make(map[ssa.Value][]ssa.CallInstruction)
 
-		// Walk the found path starting from the end.
-		// For each path item, we already know the callee:
-		// - for the last item it was found in the repository during the call to PathSearch
-		// - for other items it was added to the repository by previous iterations
-		for i := len(path) - 1; i >= 0; i-- {
-			caller := path[i].Caller
+	// Callers are walked until no new wrapper is found, because a call made by a function can only be
+	// detected once its callee is known to be a wrapper, which depends on the order of the walk.
+	graph := c.CallGraph()
+	for found := -1; found != len(wrappers.wrappers); {
+		found = len(wrappers.wrappers)
+		for _, node := range graph.Nodes {
+			// Try to find a call path leading to one of the known wrappers
+			path := callgraph.PathSearch(node, func(node *callgraph.Node) bool {
+				_, ok := wrappers.get(node)
+				return ok
+			})
+			if len(path) == 0 {
+				continue
+			}
 
-			if _, ok := wrappers.get(caller); ok {
+			if _, ok := path[0].Site.(*ssa.Call); !ok {
+				// If the path starts with anything other than a call, then it could
+				// be an anonymous function call, a call to defer or a goroutine.
+				// In these cases we know that there are longer paths which include this one
+				// as their "suffix", so we prefer processing those paths.
 				continue
 			}
 
-			for _, edge := range caller.Out {
-				// Scan all calls made by the caller, because PathSearch only returns one
-				// path amongst all possible ones.
-				// If the called function receives one of the callers parameter as its argument,
-				// then the caller will be marked as a wrapper.
-				arg := wrappers.scan(edge)
-				if arg == nil {
-					// The caller is a wrapper: the argument used for the call is one of its parameters,
-					// so there is nothing to do.
+			// Walk the found path starting from the end.
+			// For each path item, we already know the callee:
+			// - for the last item it was found in the repository during the call to PathSearch
+			// - for other items it was added to the repository by previous iterations
+			for i := len(path) - 1; i >= 0; i-- {
+				caller := path[i].Caller
+
+				if _, ok := wrappers.get(caller); ok {
 					continue
 				}
 
-				// Scan the argument used for the call to check whether it has the right type and report
-				// any eventual issues.
-				for _, issue := range c.getArgIssues(arg) {
-					c.Pass.Reportf(edge.Site.Common().Pos(), issue)
+				for _, edge := range caller.Out {
+					callee, ok := wrappers.get(edge.Callee)
+					if !ok || checked[edge.Site] {
+						continue
+					}
+					checked[edge.Site] = true
+
+					pos := edge.Site.Common().Pos()
+					if callee.ReturnsErr && c.discardsError(edge.Site) {
+						c.report(pos, newIssue(CheckIgnoredError, "error returned by %s is not checked", funcName(edge.Callee.Func)))
+					}
+
+					// Scan all calls made by the caller, because PathSearch only returns one
+					// path amongst all possible ones.
+					// If the called function receives one of the callers parameter as its argument,
+					// then the caller will be marked as a wrapper.
+					arg := wrappers.scan(edge)
+					if arg == nil {
+						// The caller is a wrapper: the argument used for the call is one of its parameters,
+						// so there is nothing to do.
+						continue
+					}
+
+					// Scan the values which can be used as the argument of the call to check whether they have
+					// the right type and report any eventual issues.
+					reported := make(map[types.Type]bool)
+					for _, root := range argumentRoots(arg) {
+						typ, fact, issues := c.getArgFact(root)
+						switch root.(type) {
+						case *ssa.Alloc, *ssa.Global:
+							if fact != nil {
+								configured[root] = append(configured[root], edge.Site)
+							}
+						}
+
+						if reported[typ] {
+							continue
+						}
+						reported[typ] = true
+
+						if fact == nil {
+							c.report(pos, issues...)
+							continue
+						}
+
+						c.report(pos, c.withOrigins(fact.Issues)...)
+
+						c.report(pos, c.requiredKeys(fact)...)
+
+						if err := c.writeSchema(typ, fact.Schema, pos); err != nil {
+							return fmt.Errorf("writing schema of %s: %w", typ, err)
+						}
+					}
 				}
 			}
 		}
 	}
 
+	c.reportMultipleConfigure(configured)
+
 	if wrappers.exported {
 		c.Pass.ExportPackageFact(new(hasWrappersFact))
 	}
+	return nil
+}
+
+// reportMultipleConfigure reports the calls configuring an allocation or a package variable which is
+// already configured by another call. The first call in the source code is not reported, nor calls made
+// by the same function which cannot both run, such as calls in the branches of an if statement.
+func (c *checker) reportMultipleConfigure(configured map[ssa.Value][]ssa.CallInstruction) {
+	for root, sites := range configured {
+		if len(sites) < 2 {
+			continue
+		}
+
+		sort.Slice(sites, func(i, j int) bool { return sites[i].Common().Pos() < sites[j].Common().Pos() })
+		for j, site := range sites[1:] {
+			for _, previous := range sites[:j+1] {
+				if !sequential(previous, site) {
+					continue
+				}
+
+				first := c.Pass.Fset.Position(previous.Common().Pos())
+				c.report(site.Common().Pos(), newIssue(CheckMultipleConfigure, "struct %s is already configured at %s:%d",
+					getStructType(root), filepath.Base(first.Filename), first.Line))
+				break
+			}
+		}
+	}
+}
+
+// sequential returns true if both calls can run one after the other. Calls made by different functions
+// are assumed to be, while calls made by the same function must be reachable one from the other.
+func sequential(a, b ssa.CallInstruction) bool {
+	if a.Parent() != b.Parent() {
+		return true
+	}
+	return reachable(a.Block(), b.Block()) || reachable(b.Block(), a.Block())
+}
+
+// reachable returns true if the given block can be reached from the other one
+func reachable(from, to *ssa.BasicBlock) bool {
+	seen := map[*ssa.BasicBlock]bool{from: true}
+	queue := []*ssa.BasicBlock{from}
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		if block == to {
+			return true
+		}
+
+		for _, succ := range block.Succs {
+			if !seen[succ] {
+				seen[succ] = true
+				queue = append(queue, succ)
+			}
+		}
+	}
+	return false
 }
 
 // wrapper contains all necessary information to trace the variable which
@@ -183,6 +314,9 @@ type wrapper struct {
 	IsInvoke bool
 	ArgIndex int
 	FreeVar  *ssa.FreeVar
+	// ReturnsErr is true when the wrapper returns the error of the call it wraps, so that its callers
+	// are responsible for checking it
+	ReturnsErr bool
 }
 
 // Fact converts wrapper into a wrapperFact. wrapper does not implement
@@ -190,8 +324,9 @@ type wrapper struct {
 // the gob package, leading to issues with golangci-lint cache.
 func (w wrapper) Fact() wrapperFact {
 	return wrapperFact{
-		IsInvoke: w.IsInvoke,
-		ArgIndex: w.ArgIndex,
+		IsInvoke:   w.IsInvoke,
+		ArgIndex:   w.ArgIndex,
+		ReturnsErr: w.ReturnsErr,
 	}
 }
 
@@ -204,21 +339,19 @@ func (w wrapper) Argument(call *ssa.CallCommon) ssa.Value {
 	}
 
 	argIndex := w.ArgIndex
-	if w.IsInvoke && argIndex >= len(call.Args) {
+	if w.IsInvoke && (call.IsInvoke() || argIndex >= len(call.Args)) {
+		// the receiver of interface method calls and bound methods is not part of the arguments
 		argIndex--
 	}
-	arg := call.Args[argIndex]
-	if unOp, ok := arg.(*ssa.UnOp); ok {
-		arg = unOp.X
-	}
-	return arg
+	return call.Args[argIndex]
 }
 
 // wrapperFact contains a subset of wrapper information, in order to comply with
 // golangci-lint gob encoding
 type wrapperFact struct {
-	IsInvoke bool
-	ArgIndex int
+	IsInvoke   bool
+	ArgIndex   int
+	ReturnsErr bool
 }
 
 func (wrapperFact) AFact() {}
@@ -228,6 +361,9 @@ func (w wrapperFact) String() string {
 	if w.IsInvoke {
 		s += ", is method"
 	}
+	if w.ReturnsErr {
+		s += ", returns error"
+	}
 	return s
 }
 
@@ -278,8 +414,9 @@ func (w *wrapperRepository) get(node *callgraph.Node) (wrapper, bool) {
 
 	// Convert the wrapperFact back to the wrapper type
 	return wrapper{
-		IsInvoke: fact.IsInvoke,
-		ArgIndex: fact.ArgIndex,
+		IsInvoke:   fact.IsInvoke,
+		ArgIndex:   fact.ArgIndex,
+		ReturnsErr: fact.ReturnsErr,
 	}, true
 }
 
@@ -319,21 +456,184 @@ func (w *wrapperRepository) scan(edge *callgraph.Edge) ssa.Value {
 
 	arg := wrapperInfo.Argument(edge.Site.Common())
 
+	// parameters captured by closures are loaded from their allocation
+	local := arg
+	if unOp, ok := arg.(*ssa.UnOp); ok {
+		local = unOp.X
+	}
+
+	// the error of the wrapped call is passed up when the wrapper returns it, possibly wrapped
+	returnsErr := wrapperInfo.ReturnsErr && returnsError(caller.Func.Signature) && returnsCallError(edge.Site)
+
 	for i, param := range caller.Func.Params {
-		if param.Pos() == arg.Pos() {
+		if param.Pos() == local.Pos() {
 			w.add(caller, wrapper{
-				ArgIndex: i,
+				ArgIndex:   i,
+				ReturnsErr: returnsErr,
 			})
 			return nil
 		}
 	}
 
-	if freeVar, ok := arg.(*ssa.FreeVar); ok {
+	if freeVar, ok := local.(*ssa.FreeVar); ok {
 		w.add(caller, wrapper{
-			FreeVar: freeVar,
+			FreeVar:    freeVar,
+			ReturnsErr: returnsErr,
 		})
 		return nil
 	}
 
 	return arg
 }
+
+// discardsError returns true if the error returned as the last result of the given call is not used,
+// either because the call is made by a go or defer statement, because the error is assigned to _
+// or because it is stored in a package variable which is never read.
+func (c *checker) discardsError(site ssa.CallInstruction) bool {
+	call, ok := site.(*ssa.Call)
+	if !ok {
+		return true
+	}
+
+	results := call.Call.Signature().Results()
+	if results.Len() == 1 {
+		return !c.isUsed(call)
+	}
+
+	for _, ref := range referrers(call) {
+		if extract, ok := ref.(*ssa.Extract); ok && extract.Index == results.Len()-1 && c.isUsed(extract) {
+			return false
+		}
+	}
+	return true
+}
+
+// isUsed returns true if the given value is used by any instruction other than a store into a package variable
+// which is never read
+func (c *checker) isUsed(value ssa.Value) bool {
+	for _, ref := range referrers(value) {
+		if store, ok := ref.(*ssa.Store); ok {
+			if global, ok := store.Addr.(*ssa.Global); ok && !c.isRead(global) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isRead returns true if the given package variable might be read. Exported variables might be read by
+// other packages, while unexported ones must be used by the current package.
+func (c *checker) isRead(global *ssa.Global) bool {
+	obj := global.Object()
+	if obj == nil || obj.Exported() {
+		return true
+	}
+
+	if c.usedVars == nil {
+		c.usedVars = make(map[types.Object]bool)
+		for _, obj := range c.Pass.TypesInfo.Uses {
+			c.usedVars[obj] = true
+		}
+	}
+	return c.usedVars[obj]
+}
+
+// returnsCallError returns true if the error returned as the last result of the given call is also returned
+// as the last result of the calling function, either directly or wrapped by fmt.Errorf or errors.Join.
+func returnsCallError(site ssa.CallInstruction) bool {
+	call, ok := site.(*ssa.Call)
+	if !ok {
+		return false
+	}
+
+	results := call.Call.Signature().Results()
+	if results.Len() == 1 {
+		return flowsToReturn(call, make(map[ssa.Value]bool))
+	}
+
+	for _, ref := range referrers(call) {
+		if extract, ok := ref.(*ssa.Extract); ok && extract.Index == results.Len()-1 {
+			return flowsToReturn(extract, make(map[ssa.Value]bool))
+		}
+	}
+	return false
+}
+
+// errorWrappers are the functions whose returned error wraps the errors they receive
+var errorWrappers = map[string]bool{
+	"fmt.Errorf":  true,
+	"errors.Join": true,
+}
+
+// flowsToReturn returns true if the given value, or an error wrapping it, is returned as the last result of
+// the function it belongs to. The value is followed through conversions, phi nodes, local variables such as
+// named results, and the variadic arguments of error wrappers.
+func flowsToReturn(value ssa.Value, seen map[ssa.Value]bool) bool {
+	if seen[value] {
+		return false
+	}
+	seen[value] = true
+
+	for _, ref := range referrers(value) {
+		var next ssa.Value
+		switch ref := ref.(type) {
+		case *ssa.Return:
+			if len(ref.Results) > 0 && ref.Results[len(ref.Results)-1] == value {
+				return true
+			}
+		case *ssa.Store:
+			if ref.Val != value {
+				// the value is the address of a local variable, which is written
+				break
+			}
+
+			// the value is stored in a local variable, or in the array holding variadic arguments,
+			// whose loads and slices are followed
+			addr := ref.Addr
+			if indexAddr, ok := addr.(*ssa.IndexAddr); ok {
+				addr = indexAddr.X
+			}
+			if _, ok := addr.(*ssa.Alloc); ok {
+				next = addr
+			}
+		case *ssa.UnOp:
+			if ref.Op == token.MUL {
+				next = ref
+			}
+		case *ssa.Call:
+			callee := ref.Call.StaticCallee()
+			if callee != nil && callee.Object() != nil && callee.Object().Pkg() != nil &&
+				errorWrappers[callee.Object().Pkg().Path()+"."+callee.Object().Name()] {
+				next = ref
+			}
+		case *ssa.Phi, *ssa.ChangeInterface, *ssa.MakeInterface, *ssa.IndexAddr, *ssa.Slice:
+			next = ref.(ssa.Value)
+		}
+
+		if next != nil && flowsToReturn(next, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// returnsError returns true if the last result of the given signature is an error
+func returnsError(sig *types.Signature) bool {
+	results := sig.Results()
+	if results.Len() == 0 {
+		return false
+	}
+	return types.Identical(results.At(results.Len()-1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// funcName returns the name of the given function as displayed in reported issues
+func funcName(fn *ssa.Function) string {
+	if fn.Parent() != nil {
+		return "anonymous function"
+	}
+	if obj := fn.Object(); obj != nil {
+		return obj.Name()
+	}
+	return fn.Name()
+}
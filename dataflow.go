@@ -0,0 +1,188 @@
+package zconfigcheck
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// argumentRoots returns the values which may be used as configuration root by a call to zconfig whose
+// argument is the given value. The argument is followed back to its allocations through conversions to
+// interfaces, phi nodes, loads of variables, struct fields, slice elements and map values, and through
+// the results of functions declared in the package.
+// Values which cannot be followed further are returned as is, so that they can be reported.
+func argumentRoots(arg ssa.Value) []ssa.Value {
+	var roots []ssa.Value
+	visited := make(map[ssa.Value]bool)
+
+	var walk func(value ssa.Value)
+	walkAll := func(values []ssa.Value, value ssa.Value) {
+		if len(values) == 0 {
+			// the origin of the value is unknown
+			roots = append(roots, value)
+			return
+		}
+		for _, v := range values {
+			walk(v)
+		}
+	}
+
+	walk = func(value ssa.Value) {
+		if visited[value] {
+			return
+		}
+		visited[value] = true
+
+		switch v := value.(type) {
+		case *ssa.MakeInterface:
+			walk(v.X)
+		case *ssa.ChangeInterface:
+			walk(v.X)
+		case *ssa.TypeAssert:
+			walk(v.X)
+		case *ssa.Const:
+			if !v.IsNil() {
+				roots = append(roots, v)
+			}
+		case *ssa.Phi:
+			for _, edge := range v.Edges {
+				walk(edge)
+			}
+		case *ssa.UnOp:
+			if v.Op != token.MUL {
+				roots = append(roots, v)
+				return
+			}
+			walkAll(storedValues(v.X), v)
+		case *ssa.Lookup:
+			walkAll(mapValues(v.X), v)
+		case *ssa.Extract:
+			walkAll(extractedValues(v), v)
+		case *ssa.Call:
+			walkAll(returnedValues(v, 0), v)
+		default:
+			roots = append(roots, v)
+		}
+	}
+
+	walk(arg)
+	if len(roots) == 0 {
+		// the argument is always nil
+		return []ssa.Value{arg}
+	}
+	return roots
+}
+
+// storedValues returns the values stored at the given address, which can be a local variable,
+// a struct field or a slice element. Elements of a slice are not distinguished by their index.
+func storedValues(addr ssa.Value) []ssa.Value {
+	var addrs []ssa.Value
+	switch a := addr.(type) {
+	case *ssa.Alloc:
+		addrs = append(addrs, a)
+	case *ssa.FieldAddr:
+		for _, ref := range referrers(a.X) {
+			if fieldAddr, ok := ref.(*ssa.FieldAddr); ok && fieldAddr.Field == a.Field {
+				addrs = append(addrs, fieldAddr)
+			}
+		}
+	case *ssa.IndexAddr:
+		addrs = elementAddrs(a.X)
+	}
+
+	var values []ssa.Value
+	for _, a := range addrs {
+		for _, ref := range referrers(a) {
+			if store, ok := ref.(*ssa.Store); ok && store.Addr == a {
+				values = append(values, store.Val)
+			}
+		}
+	}
+	return values
+}
+
+// elementAddrs returns the addresses of the elements of the given slice or array pointer,
+// including those taken from the array backing the slice.
+func elementAddrs(slice ssa.Value) []ssa.Value {
+	if s, ok := slice.(*ssa.Slice); ok {
+		slice = s.X
+	}
+
+	slices := []ssa.Value{slice}
+	for _, ref := range referrers(slice) {
+		if s, ok := ref.(*ssa.Slice); ok {
+			slices = append(slices, s)
+		}
+	}
+
+	var addrs []ssa.Value
+	for _, s := range slices {
+		for _, ref := range referrers(s) {
+			if indexAddr, ok := ref.(*ssa.IndexAddr); ok {
+				addrs = append(addrs, indexAddr)
+			}
+		}
+	}
+	return addrs
+}
+
+// mapValues returns the values stored in the given map by the function creating it
+func mapValues(m ssa.Value) []ssa.Value {
+	var values []ssa.Value
+	for _, ref := range referrers(m) {
+		if update, ok := ref.(*ssa.MapUpdate); ok && update.Map == m {
+			values = append(values, update.Value)
+		}
+	}
+	return values
+}
+
+// extractedValues returns the values which can be extracted from a tuple, either the values of a map
+// iterated with range, the value of a map lookup or the result of a function call.
+func extractedValues(extract *ssa.Extract) []ssa.Value {
+	switch tuple := extract.Tuple.(type) {
+	case *ssa.Next:
+		if extract.Index != 2 || tuple.IsString {
+			return nil
+		}
+		if rng, ok := tuple.Iter.(*ssa.Range); ok {
+			return mapValues(rng.X)
+		}
+	case *ssa.Lookup:
+		if extract.Index == 0 {
+			return mapValues(tuple.X)
+		}
+	case *ssa.Call:
+		return returnedValues(tuple, extract.Index)
+	}
+	return nil
+}
+
+// returnedValues returns the values of the result at the given index of a call to a function
+// of the current package, such as a constructor.
+func returnedValues(call *ssa.Call, index int) []ssa.Value {
+	callee := call.Call.StaticCallee()
+	if callee == nil || callee.Blocks == nil {
+		return nil
+	}
+
+	var values []ssa.Value
+	for _, block := range callee.Blocks {
+		if len(block.Instrs) == 0 {
+			continue
+		}
+		if ret, ok := block.Instrs[len(block.Instrs)-1].(*ssa.Return); ok && index < len(ret.Results) {
+			values = append(values, ret.Results[index])
+		}
+	}
+	return values
+}
+
+// referrers returns the instructions referring to the given value
+func referrers(value ssa.Value) []ssa.Instruction {
+	refs := value.Referrers()
+	if refs == nil {
+		return nil
+	}
+	return *refs
+}
@@ -0,0 +1,164 @@
+package zconfigcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+const ignoreDirective = "//zconfigcheck:ignore"
+
+// directive is a //zconfigcheck:ignore comment, silencing the issues of the given checks
+// reported on the lines it applies to.
+type directive struct {
+	Pos    token.Pos
+	Codes  string
+	Checks checkSet
+	Reason string
+
+	File     *token.File
+	FromLine int
+	ToLine   int
+
+	Used bool
+}
+
+// Matches returns true if the directive applies to the given issue reported at the given position
+func (d *directive) Matches(pos token.Pos, issue Issue) bool {
+	if !pos.IsValid() || d.File.Base() > int(pos) || int(pos) > d.File.Base()+d.File.Size() {
+		return false
+	}
+
+	line := d.File.Line(pos)
+	return line >= d.FromLine && line <= d.ToLine && d.Checks.Has(issue.Check)
+}
+
+// collectDirectives parses all //zconfigcheck:ignore comments of the package.
+// A directive applies to the syntax nodes starting on its own line when it trails some code,
+// or on the line following its comment group otherwise. This allows ignoring issues on
+// a single field or call, as well as on a whole type declaration.
+// Malformed directives are reported immediately.
+func (c *checker) collectDirectives() {
+	for _, file := range c.Pass.Files {
+		tokFile := c.Pass.Fset.File(file.Pos())
+
+		// For each line, store the end of the largest node starting on it and the position of its first token
+		nodeEnds := make(map[int]token.Pos)
+		codeStarts := make(map[int]token.Pos)
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node.(type) {
+			case nil, *ast.File, *ast.Comment, *ast.CommentGroup:
+				return node != nil
+			}
+
+			line := tokFile.Line(node.Pos())
+			if end, ok := nodeEnds[line]; !ok || node.End() > end {
+				nodeEnds[line] = node.End()
+			}
+			if start, ok := codeStarts[line]; !ok || node.Pos() < start {
+				codeStarts[line] = node.Pos()
+			}
+
+			endLine := tokFile.Line(node.End())
+			if start, ok := codeStarts[endLine]; !ok || node.End() < start {
+				codeStarts[endLine] = node.End()
+			}
+			return true
+		})
+
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, ignoreDirective) {
+					continue
+				}
+
+				d, ok := c.parseDirective(comment)
+				if !ok {
+					continue
+				}
+
+				line := tokFile.Line(comment.Pos())
+				if start, ok := codeStarts[line]; !ok || start > comment.Pos() {
+					// the directive does not trail any code, it applies to the code following the comment group
+					line = tokFile.Line(group.End()) + 1
+				}
+
+				d.File = tokFile
+				d.FromLine = line
+				d.ToLine = line
+				if end, ok := nodeEnds[line]; ok {
+					d.ToLine = tokFile.Line(end)
+				}
+				c.directives = append(c.directives, d)
+			}
+		}
+	}
+}
+
+// parseDirective parses a //zconfigcheck:ignore comment. Malformed directives are reported
+// and the returned boolean is false.
+func (c *checker) parseDirective(comment *ast.Comment) (*directive, bool) {
+	text := strings.TrimPrefix(comment.Text, ignoreDirective)
+	if text != "" && !strings.HasPrefix(text, " ") {
+		// this is another directive, e.g. //zconfigcheck:ignored
+		return nil, false
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		c.report(comment.Pos(), newIssue(CheckInvalidIgnore,
+			"malformed directive, expected: %s <code>[,<code>...] <reason>", ignoreDirective))
+		return nil, false
+	}
+
+	var checks checkSet
+	if err := checks.Set(fields[0]); err != nil {
+		c.report(comment.Pos(), newIssue(CheckInvalidIgnore, "malformed directive: %s", err))
+		return nil, false
+	}
+
+	return &directive{
+		Pos:    comment.Pos(),
+		Codes:  fields[0],
+		Checks: checks,
+		Reason: strings.Join(fields[1:], " "),
+	}, true
+}
+
+// ignored returns true if the given issue reported at the given position is silenced by a directive.
+// Matching directives are marked as used.
+func (c *checker) ignored(pos token.Pos, issue Issue) bool {
+	var ignored bool
+	for _, d := range c.directives {
+		if d.Matches(pos, issue) {
+			d.Used = true
+			ignored = true
+		}
+	}
+	return ignored
+}
+
+// withoutIgnored returns a copy of the given issues, without the ones silenced by a directive
+func (c *checker) withoutIgnored(issues Issues) Issues {
+	filtered := make(Issues)
+	for pos, posIssues := range issues {
+		for _, issue := range posIssues {
+			if !c.ignored(pos, issue) {
+				filtered.Add(pos, issue)
+			}
+		}
+	}
+	return filtered
+}
+
+// reportUnusedDirectives reports the directives which did not silence any issue.
+// It must be called once all issues of the package have been reported.
+func (c *checker) reportUnusedDirectives() {
+	for _, d := range c.directives {
+		if d.Used {
+			continue
+		}
+
+		c.report(d.Pos, newIssue(CheckUnusedIgnore, "unused %s directive for %s", ignoreDirective, d.Codes))
+	}
+}
@@ -0,0 +1,182 @@
+package zconfigcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/fatih/structtag"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Suggested fixes edit the syntax of the analyzed package, so they are only attached to issues
+// reported on the fields or methods declared in this package. They are never stored in facts.
+
+// astField returns the syntax node declaring the struct field at the given position,
+// or nil if the field is not declared in the analyzed package.
+func (c *checker) astField(pos token.Pos) *ast.Field {
+	if c.fields == nil {
+		c.fields = make(map[token.Pos]*ast.Field)
+		c.Inspector.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+			for _, field := range n.(*ast.StructType).Fields.List {
+				for _, name := range field.Names {
+					c.fields[name.Pos()] = field
+				}
+				if len(field.Names) == 0 {
+					c.fields[embeddedName(field.Type).Pos()] = field
+				}
+			}
+		})
+	}
+
+	return c.fields[pos]
+}
+
+// astCall returns the syntax node of the call whose opening parenthesis is at the given position,
+// or nil if there is no such call in the analyzed package.
+func (c *checker) astCall(pos token.Pos) *ast.CallExpr {
+	if c.calls == nil {
+		c.calls = make(map[token.Pos]*ast.CallExpr)
+		c.Inspector.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+			call := n.(*ast.CallExpr)
+			c.calls[call.Lparen] = call
+		})
+	}
+
+	return c.calls[pos]
+}
+
+// end returns the end of the syntax node highlighted by issues reported at the given position: the whole
+// declaration of a struct field, or the arguments of a call. It is token.NoPos for other positions.
+func (c *checker) end(pos token.Pos) token.Pos {
+	if field := c.astField(pos); field != nil {
+		return field.End()
+	}
+	if call := c.astCall(pos); call != nil {
+		return call.End()
+	}
+	return token.NoPos
+}
+
+// embeddedName returns the type name of an embedded field, which is the position of its types.Var
+func embeddedName(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	}
+	return expr
+}
+
+// removeTagsFix returns a fix removing the given tags from the field declared at the given position.
+// The whole tag literal is removed if no tag remains. Issues detected on the same field must remove
+// the same tags, so that their fixes do not conflict.
+func (c *checker) removeTagsFix(pos token.Pos, keys ...string) []analysis.SuggestedFix {
+	field := c.astField(pos)
+	if field == nil || field.Tag == nil {
+		return nil
+	}
+
+	rawTags, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return nil
+	}
+
+	tags, err := structtag.Parse(rawTags)
+	if err != nil {
+		return nil
+	}
+
+	var removed []string
+	for _, key := range keys {
+		if _, err := tags.Get(key); err == nil {
+			removed = append(removed, key)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	tags.Delete(removed...)
+
+	edit := analysis.TextEdit{Pos: field.Tag.Pos(), End: field.Tag.End()}
+	if tags.Len() == 0 {
+		edit.Pos = field.Type.End()
+	} else {
+		edit.NewText = []byte(quoteTags(tags.String()))
+	}
+
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Remove tags: %s", strings.Join(removed, ", ")),
+		TextEdits: []analysis.TextEdit{edit},
+	}}
+}
+
+// quoteTags returns the literal representation of the given struct tags, preferring raw strings
+func quoteTags(tags string) string {
+	if strconv.CanBackquote(tags) {
+		return "`" + tags + "`"
+	}
+	return strconv.Quote(tags)
+}
+
+// exportFieldFix returns a fix exporting the given private field of the given struct type,
+// renaming all its uses in the analyzed package. No fix is returned if the exported name
+// is already used by another field or method.
+func (c *checker) exportFieldFix(field *types.Var, typ types.Type) []analysis.SuggestedFix {
+	if field.Embedded() || c.astField(field.Pos()) == nil {
+		return nil
+	}
+
+	first, size := utf8.DecodeRuneInString(field.Name())
+	name := string(unicode.ToUpper(first)) + field.Name()[size:]
+	if !token.IsExported(name) {
+		return nil
+	}
+
+	if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), true, c.Pass.Pkg, name); obj != nil {
+		return nil
+	}
+
+	// unexported fields can only be used by the package declaring them
+	edits := []analysis.TextEdit{{Pos: field.Pos(), End: field.Pos() + token.Pos(len(field.Name())), NewText: []byte(name)}}
+	for ident, obj := range c.Pass.TypesInfo.Uses {
+		if v, ok := obj.(*types.Var); ok && v.Origin() == field.Origin() {
+			edits = append(edits, analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte(name)})
+		}
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+
+	return []analysis.SuggestedFix{{
+		Message:   fmt.Sprintf("Rename field %s to %s", field.Name(), name),
+		TextEdits: edits,
+	}}
+}
+
+// pointerReceiverFix returns a fix declaring the method at the given position on a pointer receiver
+func (c *checker) pointerReceiverFix(pos token.Pos) []analysis.SuggestedFix {
+	var fixes []analysis.SuggestedFix
+	c.Inspector.Preorder([]ast.Node{(*ast.FuncDecl)(nil)}, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		if decl.Name.Pos() != pos || decl.Recv == nil || len(decl.Recv.List) != 1 {
+			return
+		}
+
+		recv := decl.Recv.List[0].Type
+		fixes = append(fixes, analysis.SuggestedFix{
+			Message:   "Use a pointer receiver",
+			TextEdits: []analysis.TextEdit{{Pos: recv.Pos(), End: recv.Pos(), NewText: []byte("*")}},
+		})
+	})
+	return fixes
+}
@@ -0,0 +1,106 @@
+package zconfigcheck
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// disabledChecks lists the checks whose issues are not reported
+	disabledChecks checkSet
+
+	// enabledChecks lists the opt-in checks whose issues are reported
+	enabledChecks checkSet
+
+	// requiredKeysAllowlist lists the keys which are known to be required
+	requiredKeysAllowlist allowlist
+
+	// parsableTypes lists the types handled by custom zconfig parsers
+	parsableTypes stringList
+
+	// schemaDir is the directory where the schemas of configuration roots are written
+	schemaDir string
+
+	// callGraphAlgorithm is the algorithm used to build call graphs when detecting calls to zconfig
+	callGraphAlgorithm = staticCallGraph
+
+	// callGraphMaxFuncs is the number of functions of a package above which the static algorithm is used
+	callGraphMaxFuncs = 10000
+
+	// keyNamingCase is the case convention of key segments
+	keyNamingCase keyCase
+
+	// keyNamingChars is the set of characters allowed in key segments
+	keyNamingChars charSet
+
+	// keyNamingMaxDepth is the maximum number of segments of fully qualified keys, 0 for no limit
+	keyNamingMaxDepth int
+
+	// keyNamingForbiddenPrefixes lists the prefixes which fully qualified keys cannot start with
+	keyNamingForbiddenPrefixes stringList
+)
+
+func init() {
+	Analyzer.Flags.Var(&disabledChecks, "disable",
+		"comma-separated list of check names or codes to disable, amongst: "+strings.Join(checkNames(), ", "))
+	Analyzer.Flags.Var(&enabledChecks, "enable",
+		"comma-separated list of opt-in check names or codes to enable, amongst: "+optInChecks.String())
+	Analyzer.Flags.Var(&requiredKeysAllowlist, "required-keys-allowlist",
+		"file listing the keys or environment variables which are not reported by the required-key check, one per line")
+	Analyzer.Flags.Var(&parsableTypes, "parsable-types",
+		"comma-separated list of types handled by custom zconfig parsers, e.g. github.com/google/uuid.UUID")
+	Analyzer.Flags.StringVar(&schemaDir, "schema", "",
+		"directory where the JSON schema of each configuration root is written")
+	Analyzer.Flags.Var(&callGraphAlgorithm, "callgraph",
+		"algorithm used to build call graphs, amongst: static, cha, vta")
+	Analyzer.Flags.IntVar(&callGraphMaxFuncs, "callgraph-max-funcs", callGraphMaxFuncs,
+		"number of functions of a package above which the static call graph algorithm is used, 0 for no limit")
+	Analyzer.Flags.Var(&keyNamingCase, "key-case",
+		"case convention of key segments, amongst: kebab, snake, camel, lower")
+	Analyzer.Flags.Var(&keyNamingChars, "key-chars",
+		"characters allowed in key segments, as a regular expression character class, e.g. a-z0-9-")
+	Analyzer.Flags.IntVar(&keyNamingMaxDepth, "key-max-depth", 0,
+		"maximum number of dot-separated segments of keys, 0 for no limit")
+	Analyzer.Flags.Var(&keyNamingForbiddenPrefixes, "key-forbidden-prefixes",
+		"comma-separated list of prefixes which keys cannot start with")
+}
+
+// stringList is a flag.Value holding a comma-separated list of strings
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = nil
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// callGraph is a flag.Value holding the name of a call graph algorithm
+type callGraph string
+
+const (
+	staticCallGraph callGraph = "static"
+	chaCallGraph    callGraph = "cha"
+	vtaCallGraph    callGraph = "vta"
+)
+
+func (g *callGraph) String() string {
+	return string(*g)
+}
+
+func (g *callGraph) Set(value string) error {
+	switch callGraph(value) {
+	case staticCallGraph, chaCallGraph, vtaCallGraph:
+		*g = callGraph(value)
+		return nil
+	}
+	return fmt.Errorf("unknown call graph algorithm %s, must be one of: static, cha, vta", value)
+}
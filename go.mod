@@ -1,15 +1,16 @@
 module github.com/synthesio/zconfigcheck
 
-go 1.22.3
+go 1.25.0
 
 require (
 	github.com/fatih/structtag v1.2.0
 	github.com/golangci/plugin-module-register v0.1.1
 	github.com/synthesio/zconfig/v2 v2.1.0
-	golang.org/x/tools v0.22.0
+	golang.org/x/tools v0.47.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	golang.org/x/mod v0.18.0 // indirect
-	golang.org/x/sync v0.7.0 // indirect
+	golang.org/x/mod v0.37.0 // indirect
+	golang.org/x/sync v0.21.0 // indirect
 )
@@ -1,6 +1,9 @@
 package golangci
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/golangci/plugin-module-register/register"
 	"github.com/synthesio/zconfigcheck"
 	"golang.org/x/tools/go/analysis"
@@ -10,7 +13,51 @@ func init() {
 	register.Plugin(zconfigcheck.LinterName, New)
 }
 
-func New(_ any) (register.LinterPlugin, error) {
+// Settings contains the linter settings read from the golangci-lint configuration.
+// They match the flags of the zconfigcheck analyzer.
+type Settings struct {
+	Disable               []string `json:"disable"`
+	Enable                []string `json:"enable"`
+	RequiredKeysAllowlist string   `json:"required-keys-allowlist"`
+	ParsableTypes         []string `json:"parsable-types"`
+	Schema                string   `json:"schema"`
+	CallGraph             string   `json:"callgraph"`
+	CallGraphMax          *int     `json:"callgraph-max-funcs"`
+	KeyCase               string   `json:"key-case"`
+	KeyChars              string   `json:"key-chars"`
+	KeyMaxDepth           int      `json:"key-max-depth"`
+	KeyForbiddenPrefixes  []string `json:"key-forbidden-prefixes"`
+}
+
+func New(settings any) (register.LinterPlugin, error) {
+	s, err := register.DecodeSettings[Settings](settings)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := map[string]string{
+		"disable":                 strings.Join(s.Disable, ","),
+		"enable":                  strings.Join(s.Enable, ","),
+		"required-keys-allowlist": s.RequiredKeysAllowlist,
+		"parsable-types":          strings.Join(s.ParsableTypes, ","),
+		"schema":                  s.Schema,
+		"key-case":                s.KeyCase,
+		"key-chars":               s.KeyChars,
+		"key-max-depth":           strconv.Itoa(s.KeyMaxDepth),
+		"key-forbidden-prefixes":  strings.Join(s.KeyForbiddenPrefixes, ","),
+	}
+	if s.CallGraph != "" {
+		flags["callgraph"] = s.CallGraph
+	}
+	if s.CallGraphMax != nil {
+		flags["callgraph-max-funcs"] = strconv.Itoa(*s.CallGraphMax)
+	}
+	for name, value := range flags {
+		if err := zconfigcheck.Analyzer.Flags.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+
 	return &Plugin{}, nil
 }
 
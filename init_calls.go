@@ -103,7 +103,7 @@ func (c *checker) lookupInitCalls() {
 
 				// do not report issues on methods generated due to generic type instantiation
 				if e.Site.Pos().IsValid() {
-					c.Pass.Reportf(e.Site.Pos(), "Init method is already invoked by zconfig")
+					c.report(e.Site.Pos(), newIssue(CheckInitDoubleCall, "Init method is already invoked by zconfig"))
 				}
 
 				return
@@ -126,7 +126,7 @@ func (c *checker) lookupInitCalls() {
 			}
 
 			if _, ok := indices[field.Field]; ok {
-				c.Pass.Reportf(e.Site.Pos(), "Init method is already invoked by zconfig")
+				c.report(e.Site.Pos(), newIssue(CheckInitDoubleCall, "Init method is already invoked by zconfig"))
 			}
 		})
 	}
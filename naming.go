@@ -0,0 +1,162 @@
+package zconfigcheck
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// keyCase is a flag.Value holding the case convention of key segments
+type keyCase string
+
+const (
+	anyCase   keyCase = ""
+	kebabCase keyCase = "kebab"
+	snakeCase keyCase = "snake"
+	camelCase keyCase = "camel"
+	lowerCase keyCase = "lower"
+)
+
+func (k *keyCase) String() string {
+	return string(*k)
+}
+
+func (k *keyCase) Set(value string) error {
+	switch keyCase(value) {
+	case anyCase, kebabCase, snakeCase, camelCase, lowerCase:
+		*k = keyCase(value)
+		return nil
+	}
+	return fmt.Errorf("unknown key case %s, must be one of: kebab, snake, camel, lower", value)
+}
+
+// format returns the segment made of the given lowercase words in the case convention
+func (k keyCase) format(words []string) string {
+	switch k {
+	case kebabCase:
+		return strings.Join(words, "-")
+	case snakeCase:
+		return strings.Join(words, "_")
+	case lowerCase:
+		return strings.Join(words, "")
+	case camelCase:
+		var b strings.Builder
+		for i, word := range words {
+			if i > 0 && word != "" {
+				word = strings.ToUpper(word[:1]) + word[1:]
+			}
+			b.WriteString(word)
+		}
+		return b.String()
+	}
+	return strings.Join(words, "")
+}
+
+// charSet is a flag.Value holding the characters allowed in key segments, in the syntax of
+// a regular expression character class, e.g. a-z0-9-
+type charSet struct {
+	chars  string
+	regexp *regexp.Regexp
+}
+
+func (s *charSet) String() string {
+	return s.chars
+}
+
+func (s *charSet) Set(value string) error {
+	if value == "" {
+		*s = charSet{}
+		return nil
+	}
+
+	re, err := regexp.Compile("^[" + value + "]*$")
+	if err != nil {
+		return fmt.Errorf("invalid key characters %s: %w", value, err)
+	}
+	*s = charSet{chars: value, regexp: re}
+	return nil
+}
+
+// Allows returns true if the given string only contains allowed characters
+func (s charSet) Allows(str string) bool {
+	return s.regexp == nil || s.regexp.MatchString(str)
+}
+
+// checkKeyName returns the issues of a key tag whose segments do not follow the case convention and the
+// characters set by the key-case and key-chars options
+func checkKeyName(field StructField) []Issue {
+	var issues []Issue
+
+	segments := strings.Split(field.Key, ".")
+	if keyNamingCase != anyCase {
+		expected := make([]string, len(segments))
+		for i, segment := range segments {
+			expected[i] = keyNamingCase.format(keyWords(segment))
+		}
+
+		if suggestion := strings.Join(expected, "."); suggestion != field.Key {
+			issues = append(issues, newIssue(CheckKeyNaming, "key '%s' of field %s is not in %s case, expected '%s'",
+				field.Key, field.Path, keyNamingCase, suggestion))
+		}
+	}
+
+	for _, segment := range segments {
+		if !keyNamingChars.Allows(segment) {
+			issues = append(issues, newIssue(CheckKeyNaming, "key '%s' of field %s contains characters other than [%s]",
+				field.Key, field.Path, keyNamingChars.chars))
+			break
+		}
+	}
+
+	return issues
+}
+
+// checkKeyPath returns the issues of a key added to a scope, whose depth or prefix is not allowed by the
+// key-max-depth and key-forbidden-prefixes options. The key is fully qualified from the struct owning the scope.
+func checkKeyPath(field StructField) []Issue {
+	var issues []Issue
+
+	if depth := strings.Count(field.Key, ".") + 1; keyNamingMaxDepth > 0 && depth > keyNamingMaxDepth {
+		issues = append(issues, newIssue(CheckKeyNaming, "key '%s' of field %s has %d levels, more than the maximum of %d",
+			field.Key, field.Path, depth, keyNamingMaxDepth))
+	}
+
+	for _, prefix := range keyNamingForbiddenPrefixes {
+		if strings.HasPrefix(field.Key, prefix) {
+			issues = append(issues, newIssue(CheckKeyNaming, "key '%s' of field %s starts with forbidden prefix '%s'",
+				field.Key, field.Path, prefix))
+		}
+	}
+
+	return issues
+}
+
+// keyWords splits a key segment into lowercase words, separated by any character other than letters and digits,
+// or by a change of case, e.g. dbHost, DBHost, db-host and db_host are all made of the words db and host
+func keyWords(segment string) []string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = nil
+		}
+	}
+
+	runes := []rune(segment)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	return words
+}
@@ -0,0 +1,338 @@
+package zconfigcheck
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"math"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// valueParser models how zconfig.ParseString handles a given type.
+type valueParser struct {
+	// Type is the name of the parsed type, as displayed in issues
+	Type string
+	// Format describes the values accepted by the parser
+	Format string
+	// Parse returns an error if the given raw value would be rejected by zconfig.
+	// It is nil when values cannot be checked statically, e.g. for encoding.TextUnmarshaler
+	// implementations.
+	Parse func(raw string) error
+}
+
+// knownParsers contains the parsers of standard library types handled by zconfig.ParseString,
+// either explicitly or because they implement encoding.TextUnmarshaler or encoding.BinaryUnmarshaler.
+// Unlike other unmarshalers, the values they accept are known and can be checked.
+var knownParsers = map[string]valueParser{
+	"time.Duration": {
+		Format: "a duration with a unit suffix, such as '300ms' or '1h30m'",
+		Parse: func(raw string) error {
+			_, err := time.ParseDuration(raw)
+			return err
+		},
+	},
+	"time.Time": {
+		Format: "an RFC 3339 timestamp",
+		Parse: func(raw string) error {
+			_, err := time.Parse(time.RFC3339, raw)
+			return err
+		},
+	},
+	"regexp.Regexp": {
+		Format: "a valid regular expression",
+		Parse: func(raw string) error {
+			_, err := regexp.Compile(raw)
+			return err
+		},
+	},
+	"net.IP": {
+		Format: "an IPv4 or IPv6 address",
+		Parse: textUnmarshaler(func(raw string) error {
+			if net.ParseIP(raw) == nil {
+				return errors.New("invalid IP address")
+			}
+			return nil
+		}),
+	},
+	"net/netip.Addr": {
+		Format: "an IPv4 or IPv6 address",
+		Parse: textUnmarshaler(func(raw string) error {
+			_, err := netip.ParseAddr(raw)
+			return err
+		}),
+	},
+	"net/netip.AddrPort": {
+		Format: "an IP address and a port, such as '127.0.0.1:80' or '[::1]:80'",
+		Parse: textUnmarshaler(func(raw string) error {
+			_, err := netip.ParseAddrPort(raw)
+			return err
+		}),
+	},
+	"net/netip.Prefix": {
+		Format: "an IP network prefix in CIDR notation, such as '10.0.0.0/8'",
+		Parse: textUnmarshaler(func(raw string) error {
+			_, err := netip.ParsePrefix(raw)
+			return err
+		}),
+	},
+	"net/url.URL": {
+		Format: "a valid URL",
+		Parse: func(raw string) error {
+			_, err := url.Parse(raw)
+			return err
+		},
+	},
+}
+
+// lookupParser returns the parser used by zconfig.ParseString for a field of the given type.
+// The returned boolean is false when zconfig.ParseString cannot handle the type.
+func lookupParser(typ types.Type) (valueParser, bool) {
+	// zconfig always calls its parsers with a pointer to the field, unless the field is already a pointer
+	res := typ
+	if _, ok := typ.(*types.Pointer); !ok {
+		res = types.NewPointer(typ)
+	}
+	elem := types.Unalias(res.(*types.Pointer).Elem())
+	name := types.TypeString(elem, nil)
+
+	if named, ok := elem.(*types.Named); ok && named.Obj().Pkg() != nil {
+		if parser, ok := knownParsers[named.Obj().Pkg().Path()+"."+named.Obj().Name()]; ok {
+			parser.Type = name
+			return parser, true
+		}
+	}
+
+	if implementsUnmarshaler(res, "UnmarshalText") || implementsUnmarshaler(res, "UnmarshalBinary") {
+		return valueParser{Type: name, Format: fmt.Sprintf("a value accepted by %s", name)}, true
+	}
+
+	if _, ok := elem.(*types.Named); ok {
+		// zconfig.ParseString does not handle named types which are not explicitly listed,
+		// even if their underlying type is supported
+		return valueParser{}, false
+	}
+
+	if slice, ok := elem.(*types.Slice); ok {
+		basic, ok := types.Unalias(slice.Elem()).(*types.Basic)
+		if !ok {
+			return valueParser{}, false
+		}
+
+		return builtinParser("[]" + basic.Name())
+	}
+
+	basic, ok := elem.(*types.Basic)
+	if !ok {
+		return valueParser{}, false
+	}
+
+	return builtinParser(basic.Name())
+}
+
+// builtinParser returns the parser used by zconfig.ParseString for the given builtin type,
+// or slice of builtin type. The returned boolean is false when zconfig.ParseString cannot handle the type.
+func builtinParser(name string) (valueParser, bool) {
+	switch name {
+	case "string", "[]string", "[]byte", "[]uint8":
+		return valueParser{Type: name, Format: "any string"}, true
+	case "[]int":
+		return sliceParser(name, "integers", strconv.IntSize), true
+	case "[]int64":
+		return sliceParser(name, "integers", 64), true
+	case "bool":
+		return valueParser{
+			Type:   name,
+			Format: "a boolean such as 'true', 'false', '1' or '0'",
+			Parse: func(raw string) error {
+				_, err := strconv.ParseBool(raw)
+				return err
+			},
+		}, true
+	case "int":
+		return intParser(name, strconv.IntSize), true
+	case "int8":
+		return intParser(name, 8), true
+	case "int16":
+		return intParser(name, 16), true
+	case "int32", "rune":
+		return intParser(name, 32), true
+	case "int64":
+		return intParser(name, 64), true
+	case "uint":
+		return uintParser(name, strconv.IntSize), true
+	case "uint8", "byte":
+		return uintParser(name, 8), true
+	case "uint16":
+		return uintParser(name, 16), true
+	case "uint32":
+		return uintParser(name, 32), true
+	case "uint64":
+		return uintParser(name, 64), true
+	case "float32":
+		return floatParser(name, 32), true
+	case "float64":
+		return floatParser(name, 64), true
+	}
+
+	return valueParser{}, false
+}
+
+// CheckValue returns an error if zconfig cannot parse the given raw value for a field of the given type,
+// named as in configuration schemas. Values of types whose parser is not known are not checked.
+func CheckValue(typ, raw string) error {
+	name := strings.TrimPrefix(typ, "*")
+	parser, ok := knownParsers[name]
+	if !ok {
+		parser, ok = builtinParser(name)
+	}
+	if !ok || parser.Parse == nil {
+		return nil
+	}
+
+	if err := parser.Parse(raw); err != nil {
+		return fmt.Errorf("expected %s", parser.Format)
+	}
+	return nil
+}
+
+func intParser(name string, bitSize int) valueParser {
+	return valueParser{
+		Type:   name,
+		Format: fmt.Sprintf("an integer between %d and %d", int64(math.MinInt64)>>(64-bitSize), int64(math.MaxInt64)>>(64-bitSize)),
+		Parse: func(raw string) error {
+			_, err := strconv.ParseInt(raw, 10, bitSize)
+			return err
+		},
+	}
+}
+
+func uintParser(name string, bitSize int) valueParser {
+	return valueParser{
+		Type:   name,
+		Format: fmt.Sprintf("an unsigned integer between 0 and %d", uint64(math.MaxUint64)>>(64-bitSize)),
+		Parse: func(raw string) error {
+			_, err := strconv.ParseUint(raw, 10, bitSize)
+			return err
+		},
+	}
+}
+
+func floatParser(name string, bitSize int) valueParser {
+	return valueParser{
+		Type:   name,
+		Format: "a floating point number",
+		Parse: func(raw string) error {
+			_, err := strconv.ParseFloat(raw, bitSize)
+			return err
+		},
+	}
+}
+
+// textUnmarshaler returns a parser modeled on the UnmarshalText method of net.IP and net/netip types,
+// which accept the empty string as the zero value.
+func textUnmarshaler(parse func(raw string) error) func(raw string) error {
+	return func(raw string) error {
+		if raw == "" {
+			return nil
+		}
+		return parse(raw)
+	}
+}
+
+// sliceParser returns a parser for comma-separated lists of integers.
+// Empty items are ignored by zconfig.
+func sliceParser(name, items string, bitSize int) valueParser {
+	return valueParser{
+		Type:   name,
+		Format: fmt.Sprintf("a comma-separated list of %s", items),
+		Parse: func(raw string) error {
+			for _, item := range strings.Split(raw, ",") {
+				item = strings.TrimSpace(item)
+				if item == "" {
+					continue
+				}
+
+				if _, err := strconv.ParseInt(item, 10, bitSize); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// implementsUnmarshaler returns true if the given type implements a method with the given name
+// and the func([]byte) error signature, as encoding.TextUnmarshaler and encoding.BinaryUnmarshaler do.
+func implementsUnmarshaler(typ types.Type, method string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, method)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 {
+		return false
+	}
+
+	param, ok := sig.Params().At(0).Type().(*types.Slice)
+	if !ok {
+		return false
+	}
+
+	elem, ok := param.Elem().(*types.Basic)
+	return ok && elem.Kind() == types.Byte &&
+		sig.Results().Len() == 1 &&
+		sig.Results().At(0).Type().String() == "error"
+}
+
+// isParsableType returns true if the given type is declared as handled by a custom parser
+// using the parsable-types option.
+func isParsableType(typ types.Type) bool {
+	name := types.TypeString(typ, nil)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		name = types.TypeString(ptr.Elem(), nil)
+	}
+
+	for _, parsable := range parsableTypes {
+		if strings.TrimPrefix(parsable, "*") == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLeaf returns any issues with the type and default tag value of the given configurable field.
+// Fields whose type is handled by a custom parser are not checked.
+func checkLeaf(field StructField) []Issue {
+	if field.IsGeneric || field.IsTarget || isParsableType(field.typeOrConstraint) {
+		return nil
+	}
+
+	parser, ok := lookupParser(field.typeOrConstraint)
+	if !ok {
+		return []Issue{newIssue(CheckUnparsableType,
+			"type %s is not handled by zconfig default parsers, configuring field %s will fail",
+			field.typeOrConstraint, field.Path,
+		)}
+	}
+
+	if !field.HasDefault || parser.Parse == nil {
+		return nil
+	}
+
+	if err := parser.Parse(field.Default); err == nil {
+		return nil
+	}
+
+	return []Issue{newIssue(CheckDefaultValue,
+		"default value '%s' cannot be parsed as %s: expected %s",
+		field.Default, parser.Type, parser.Format,
+	)}
+}
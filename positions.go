@@ -0,0 +1,109 @@
+package zconfigcheck
+
+import (
+	"go/token"
+)
+
+// Position is a position in the source code described by its line and column, which remains valid in other
+// packages unlike token.Pos. The end of the position is optional.
+type Position struct {
+	Filename  string
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+}
+
+// IsValid returns true if the position is set
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// position returns the Position matching the given token.Pos, whose end is computed from the syntax of the package.
+// An invalid Position is returned for an invalid token.Pos.
+func (c *checker) position(pos token.Pos) Position {
+	if !pos.IsValid() {
+		return Position{}
+	}
+
+	start := c.Pass.Fset.Position(pos)
+	position := Position{Filename: start.Filename, Line: start.Line, Column: start.Column}
+	if end := c.end(pos); end.IsValid() {
+		endPosition := c.Pass.Fset.Position(end)
+		position.EndLine, position.EndColumn = endPosition.Line, endPosition.Column
+	}
+	return position
+}
+
+// withOrigins returns copies of the given issues with related information pointing to the position where
+// they were detected. It is used when the issues of a configured struct are reported at a call to zconfig.
+func (c *checker) withOrigins(issues []Issue) []Issue {
+	withOrigins := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if pos, end := c.resolvePosition(issue.Position); pos.IsValid() {
+			issue = issue.WithRelatedRange(pos, end, "issue detected here")
+		}
+		withOrigins = append(withOrigins, issue)
+	}
+	return withOrigins
+}
+
+// resolvePosition returns the start and end positions matching the given Position in the file set of the pass.
+// NoPos is returned when the position cannot be resolved, e.g. when its file is not part of the file set.
+func (c *checker) resolvePosition(position Position) (token.Pos, token.Pos) {
+	file := c.file(position.Filename)
+	if file == nil || !position.IsValid() {
+		return token.NoPos, token.NoPos
+	}
+
+	pos := lineColumn(file, position.Line, position.Column)
+	end := lineColumn(file, position.EndLine, position.EndColumn)
+	if end <= pos {
+		end = token.NoPos
+	}
+	return pos, end
+}
+
+// lineColumn returns the position of the given line and column in the file.
+// NoPos is returned when the line is not part of the file.
+func lineColumn(file *token.File, line, col int) token.Pos {
+	if line <= 0 || line > file.LineCount() {
+		return token.NoPos
+	}
+
+	// Files created from export data only describe lines, so the column is ignored
+	// when it is past the end of the line
+	end := file.Base() + file.Size()
+	if line < file.LineCount() {
+		end = int(file.LineStart(line + 1))
+	}
+
+	pos := file.LineStart(line) + token.Pos(max(col, 1)-1)
+	if int(pos) >= end {
+		return file.LineStart(line)
+	}
+	return pos
+}
+
+// file returns the file of the file set of the pass with the given name. The file set is shared with the other
+// passes of the driver, so it is never modified: files of other packages are only found when they were added by
+// the driver, e.g. from export data. Nil is returned when no such file exists.
+func (c *checker) file(filename string) *token.File {
+	if file, ok := c.files[filename]; ok {
+		return file
+	}
+	if c.files == nil {
+		c.files = make(map[string]*token.File)
+	}
+
+	var file *token.File
+	c.Pass.Fset.Iterate(func(f *token.File) bool {
+		if f.Name() == filename {
+			file = f
+		}
+		return file == nil
+	})
+
+	c.files[filename] = file
+	return file
+}
@@ -0,0 +1,86 @@
+package zconfigcheck
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// requiredKeys returns an issue for each key of a configuration root which has no default value,
+// unless the key or its environment variable is listed in the file set by the required-keys-allowlist option.
+// Nothing is returned when the required-key check is not enabled.
+func (c *checker) requiredKeys(fact *structFact) []Issue {
+	if !CheckRequiredKey.Enabled() {
+		return nil
+	}
+
+	var issues []Issue
+	for _, key := range fact.Schema.Keys {
+		if !key.Required || requiredKeysAllowlist.keys[key.Key] || requiredKeysAllowlist.keys[key.Env] {
+			continue
+		}
+
+		issue := newIssue(CheckRequiredKey, "key %s (env %s) of field %s has no default value and must be provided",
+			key.Key, key.Env, key.Path)
+		for _, field := range fact.Scope.Keys[key.Key] {
+			if pos, end := c.resolvePosition(field.Position); field.Path == key.Path && pos.IsValid() {
+				issue = issue.WithRelatedRange(pos, end, "key %s is read by field %s", key.Key, key.Path)
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// allowlist is a flag.Value holding the keys and environment variables listed in a file.
+// The file is read when the flag is set, so that a relative path is resolved against the working directory
+// at that time and an unreadable file is reported once, instead of failing the analysis of each package.
+type allowlist struct {
+	path string
+	keys map[string]bool
+}
+
+func (a *allowlist) String() string {
+	return a.path
+}
+
+func (a *allowlist) Set(value string) error {
+	if value == "" {
+		*a = allowlist{}
+		return nil
+	}
+
+	path, err := filepath.Abs(value)
+	if err != nil {
+		return err
+	}
+
+	keys, err := readAllowlist(path)
+	if err != nil {
+		return fmt.Errorf("reading required keys allowlist: %w", err)
+	}
+
+	*a = allowlist{path: path, keys: keys}
+	return nil
+}
+
+// readAllowlist returns the lines of the given file. Empty lines and lines starting with # are ignored.
+func readAllowlist(path string) (map[string]bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keys := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			keys[line] = true
+		}
+	}
+	return keys, scanner.Err()
+}
@@ -0,0 +1,139 @@
+package zconfigcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+// Schema describes the configuration keys read by zconfig for a configuration root.
+// Schemas are stored in facts, so they must only contain types which can be encoded by the gob package.
+type Schema struct {
+	// Root is the name of the configured struct type. Anonymous structs are named after the
+	// position of the zconfig call.
+	Root       string            `json:"root"`
+	Keys       []SchemaKey       `json:"keys"`
+	Injections []SchemaInjection `json:"injections,omitempty"`
+}
+
+// SchemaKey describes a configuration key
+type SchemaKey struct {
+	// Key is the full dotted key, as read from the command line arguments
+	Key string `json:"key"`
+	// Env is the name of the environment variable matching the key
+	Env string `json:"env"`
+	// Path is the path of the configured field from the configuration root
+	Path string `json:"path"`
+	// Type is the Go type of the configured field
+	Type        string  `json:"type"`
+	Default     *string `json:"default,omitempty"`
+	Description string  `json:"description,omitempty"`
+	// Required is true when zconfig fails if the key is not provided, because it has no default value
+	Required bool `json:"required"`
+}
+
+// SchemaInjection describes the fields linked by an injection alias
+type SchemaInjection struct {
+	Alias   string   `json:"alias"`
+	Sources []string `json:"sources"`
+	Targets []string `json:"targets"`
+}
+
+// Schema returns the configuration schema of the struct, used as configuration root
+func (s StructInfo) Schema() Schema {
+	var schema Schema
+	for key, fields := range s.Scope.Keys {
+		for _, field := range fields {
+			if field.IsTarget {
+				// zconfig does not read keys of injection targets
+				continue
+			}
+
+			schemaKey := SchemaKey{
+				Key:         key,
+				Env:         zconfig.EnvProvider{}.FormatKey(key),
+				Path:        field.Path,
+				Type:        field.typeOrConstraint.String(),
+				Description: field.Description,
+				Required:    !field.HasDefault,
+			}
+			if field.HasDefault {
+				def := field.Default
+				schemaKey.Default = &def
+			}
+			schema.Keys = append(schema.Keys, schemaKey)
+		}
+	}
+	sort.Slice(schema.Keys, func(i, j int) bool {
+		if schema.Keys[i].Key != schema.Keys[j].Key {
+			return schema.Keys[i].Key < schema.Keys[j].Key
+		}
+		return schema.Keys[i].Path < schema.Keys[j].Path
+	})
+
+	aliases := make(map[string]*SchemaInjection)
+	injection := func(alias string) *SchemaInjection {
+		if _, ok := aliases[alias]; !ok {
+			aliases[alias] = &SchemaInjection{Alias: alias, Sources: []string{}, Targets: []string{}}
+		}
+		return aliases[alias]
+	}
+	for alias, fields := range s.Scope.Sources {
+		for _, field := range fields {
+			injection(alias).Sources = append(injection(alias).Sources, field.Path)
+		}
+	}
+	for alias, fields := range s.Scope.Targets {
+		for _, field := range fields {
+			injection(alias).Targets = append(injection(alias).Targets, field.Path)
+		}
+	}
+	for _, injection := range aliases {
+		sort.Strings(injection.Sources)
+		sort.Strings(injection.Targets)
+		schema.Injections = append(schema.Injections, *injection)
+	}
+	sort.Slice(schema.Injections, func(i, j int) bool {
+		return schema.Injections[i].Alias < schema.Injections[j].Alias
+	})
+
+	return schema
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// writeSchema writes the schema of the given configuration root, configured by a zconfig call at the given position,
+// into the directory set by the schema option. Nothing is done if the option is not set.
+func (c *checker) writeSchema(typ types.Type, schema Schema, pos token.Pos) error {
+	if schemaDir == "" {
+		return nil
+	}
+
+	schema.Root = typ.String()
+	if _, ok := typ.(*types.Named); !ok {
+		position := c.Pass.Fset.Position(pos)
+		schema.Root = fmt.Sprintf("%s/%s:%d", c.Pass.Pkg.Path(), filepath.Base(position.Filename), position.Line)
+	}
+	if schema.Keys == nil {
+		schema.Keys = []SchemaKey{}
+	}
+
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(schemaDir, 0o755); err != nil {
+		return err
+	}
+
+	name := unsafeFileChars.ReplaceAllString(schema.Root, "_") + ".json"
+	return os.WriteFile(filepath.Join(schemaDir, name), append(content, '\n'), 0o644)
+}
@@ -2,8 +2,11 @@ package zconfigcheck
 
 import (
 	"fmt"
+	"go/token"
+	"go/types"
 	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/synthesio/zconfig/v2"
 )
@@ -28,14 +31,14 @@ func NewScope() Scope {
 
 // CheckSource returns any possible issues which would arise if the given
 // field is added to the scope as an injection source.
-func (i *Scope) CheckSource(field StructField) []string {
-	var issues []string
+func (i *Scope) CheckSource(field StructField) []Issue {
+	var issues []Issue
 	for _, sourceField := range i.Sources[field.Alias] {
 		if sourceField.Pos == field.Pos {
 			continue
 		}
 
-		issues = append(issues, fmt.Sprintf(
+		issues = append(issues, newIssue(CheckDuplicateSource,
 			"inject-as alias '%s' defined by field %s is already used by field %s",
 			field.Alias, field.Path, sourceField.Path,
 		))
@@ -45,9 +48,9 @@ func (i *Scope) CheckSource(field StructField) []string {
 		if field.AssignableTo(target) {
 			continue
 		}
-		issues = append(issues, fmt.Sprintf(
-			"injection alias '%s': cannot inject source field '%s' into target field '%s', mismatched types",
-			field.Alias, field, target,
+		issues = append(issues, newIssue(CheckInjectionMismatch,
+			"injection alias '%s': cannot inject source field '%s' into target field '%s', mismatched types%s",
+			field.Alias, field, target, field.mismatchReason(target),
 		))
 	}
 
@@ -61,8 +64,8 @@ func (i *Scope) AddSource(field StructField) {
 
 // CheckTarget returns any possible issues which would arise if the given
 // field is added to the scope as an injection target.
-func (i *Scope) CheckTarget(field StructField) []string {
-	var issues []string
+func (i *Scope) CheckTarget(field StructField) []Issue {
+	var issues []Issue
 
 	targets := i.Targets[field.Alias]
 	for _, target := range targets {
@@ -70,7 +73,7 @@ func (i *Scope) CheckTarget(field StructField) []string {
 			continue
 		}
 
-		issues = append(issues, fmt.Sprintf(
+		issues = append(issues, newIssue(CheckInjectionMismatch,
 			"injection alias '%s': target fields '%s' and '%s' are incompatible, mismatched types",
 			field.Alias, field, target,
 		))
@@ -80,9 +83,9 @@ func (i *Scope) CheckTarget(field StructField) []string {
 		if src.AssignableTo(field) {
 			continue
 		}
-		issues = append(issues, fmt.Sprintf(
-			"injection alias '%s': target field '%s' cannot be injected with source field '%s', mismatched types",
-			field.Alias, field, src,
+		issues = append(issues, newIssue(CheckInjectionMismatch,
+			"injection alias '%s': target field '%s' cannot be injected with source field '%s', mismatched types%s",
+			field.Alias, field, src, src.mismatchReason(field),
 		))
 	}
 
@@ -96,33 +99,35 @@ func (i *Scope) AddTarget(field StructField) {
 
 // CheckKey returns any possible issues which would arise if the given
 // field is added to the scope as a configuration key.
-func (i *Scope) CheckKey(field StructField) []string {
+func (i *Scope) CheckKey(field StructField) []Issue {
 	for _, key := range i.Keys[field.Key] {
 		if key.Pos == field.Pos {
 			continue
 		}
 
-		return []string{fmt.Sprintf("key '%s' defined by field '%s' is already used by field '%s'", field.Key, field.Path, key.Path)}
+		return []Issue{newIssue(CheckDuplicateKey,
+			"key '%s' defined by field '%s' is already used by field '%s'", field.Key, field.Path, key.Path)}
 	}
 
+	issues := i.checkSimilarKeys(field)
+
 	envKey := zconfig.EnvProvider{}.FormatKey(field.Key)
 	if envKey == field.Key {
-		return nil
+		return issues
 	}
 
 	keys, ok := i.EnvKeys[envKey]
 	if !ok {
-		return nil
+		return issues
 	}
 
-	var issues []string
 	for _, key := range keys {
 		if key == field.Key {
 			continue
 		}
 
 		issues = append(issues,
-			fmt.Sprintf(
+			newIssue(CheckEnvCollision,
 				"key '%s' used by field '%s' and key '%s' used by field %s have the same env format '%s'",
 				field.Key,
 				field.Path,
@@ -136,6 +141,36 @@ func (i *Scope) CheckKey(field StructField) []string {
 	return issues
 }
 
+// checkSimilarKeys returns an issue if the key of the given field is similar to another key of the scope
+// sharing the same parent, because they differ only by case, separators or a typo. Keys having the same
+// environment variable name are not reported, since they are already reported as collisions.
+func (i *Scope) checkSimilarKeys(field StructField) []Issue {
+	parent, name := splitKey(field.Key)
+	envKey := zconfig.EnvProvider{}.FormatKey(field.Key)
+
+	var candidates []string
+	for key, fields := range i.Keys {
+		keyParent, keyName := splitKey(key)
+		if key == field.Key || keyParent != parent || fields[0].Pos == field.Pos ||
+			(zconfig.EnvProvider{}).FormatKey(key) == envKey {
+			continue
+		}
+		if normalizeKey(keyName) == normalizeKey(name) || similarKeyNames(keyName, name) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Strings(candidates)
+	similar := i.Keys[candidates[0]][0]
+	return []Issue{newIssue(CheckSimilarKey,
+		"key '%s' defined by field '%s' is similar to key '%s' used by field '%s', did you mean '%s'?",
+		field.Key, field.Path, similar.Key, similar.Path, similar.Key,
+	).WithRelated(similar.Pos, "key '%s' is used by field %s", similar.Key, similar.Path)}
+}
+
 // AddKey adds the given field to the scope as a configuration target
 func (i *Scope) AddKey(field StructField) {
 	i.Keys[field.Key] = append(i.Keys[field.Key], field)
@@ -225,3 +260,237 @@ func (i Scope) UnresolvedTargets() map[string][]string {
 
 	return unresolved
 }
+
+// UnusedSources returns a map whose keys are all the source aliases
+// for which no target aliases are defined.
+// The map values are lists of paths where the source alias is defined.
+func (i Scope) UnusedSources() map[string][]string {
+	unused := make(map[string][]string)
+
+	for alias, sources := range i.Sources {
+		if _, ok := i.Targets[alias]; ok {
+			continue
+		}
+
+		for _, source := range sources {
+			unused[alias] = append(unused[alias], source.Path)
+		}
+	}
+
+	return unused
+}
+
+// Fact converts the Scope into a scopeFact, whose field positions are converted with the given function
+func (i Scope) Fact(position func(token.Pos) Position) scopeFact {
+	fields := func(aliases map[string][]StructField) map[string][]scopeField {
+		facts := make(map[string][]scopeField, len(aliases))
+		for alias, structFields := range aliases {
+			for _, field := range structFields {
+				facts[alias] = append(facts[alias], scopeField{
+					Path:        field.Path,
+					Key:         field.Key,
+					Default:     field.Default,
+					HasDefault:  field.HasDefault,
+					Description: field.Description,
+					Alias:       field.Alias,
+					IsTarget:    field.IsTarget,
+					Pos:         field.Pos,
+					Position:    position(field.Pos),
+				})
+			}
+		}
+		return facts
+	}
+
+	return scopeFact{
+		Sources: fields(i.Sources),
+		Targets: fields(i.Targets),
+		Keys:    fields(i.Keys),
+	}
+}
+
+// scopeFact contains a subset of Scope information, in order to comply with golangci-lint gob encoding.
+// Fields are identified by their path from the struct owning the scope, so that their types can be
+// resolved from any instantiation of this struct, including in other packages.
+type scopeFact struct {
+	Sources map[string][]scopeField
+	Targets map[string][]scopeField
+	Keys    map[string][]scopeField
+}
+
+// scopeField identifies a field of a scope
+type scopeField struct {
+	Path        string
+	Key         string
+	Default     string
+	HasDefault  bool
+	Description string
+	Alias       string
+	IsTarget    bool
+	Pos         token.Pos
+
+	// Position is the position of the field, because Pos is only valid in the package declaring the field
+	Position Position
+}
+
+// Positions returns the positions of the fields of the scope
+func (f scopeFact) Positions() map[token.Pos]Position {
+	positions := make(map[token.Pos]Position)
+	for _, fields := range []map[string][]scopeField{f.Sources, f.Targets, f.Keys} {
+		for _, aliasFields := range fields {
+			for _, field := range aliasFields {
+				positions[field.Pos] = field.Position
+			}
+		}
+	}
+	return positions
+}
+
+// Resolve returns the Scope of the given struct type, whose fields are described by the fact.
+// Fields whose path cannot be found in the struct type are ignored.
+func (f scopeFact) Resolve(typ types.Type) Scope {
+	scope := NewScope()
+	resolve := func(fields map[string][]scopeField, add func(StructField)) {
+		for _, aliasFields := range fields {
+			for _, field := range aliasFields {
+				v, index := lookupFieldPath(typ, field.Path)
+				if v == nil {
+					continue
+				}
+
+				structField := newStructField(v, index)
+				structField.Path = field.Path
+				structField.Key = field.Key
+				structField.Default = field.Default
+				structField.HasDefault = field.HasDefault
+				structField.Description = field.Description
+				structField.Alias = field.Alias
+				structField.IsTarget = field.IsTarget
+				structField.Pos = field.Pos
+				add(structField)
+			}
+		}
+	}
+
+	resolve(f.Sources, func(field StructField) {
+		field.IsSource = true
+		scope.AddSource(field)
+	})
+	resolve(f.Targets, scope.AddTarget)
+	resolve(f.Keys, scope.AddKey)
+
+	return scope
+}
+
+// lookupFieldPath returns the field of the given struct type designated by a dot-separated path of field names,
+// and its index in the struct declaring it. Nil is returned when no such field exists.
+func lookupFieldPath(typ types.Type, path string) (*types.Var, int) {
+	var field *types.Var
+	var index int
+	for _, name := range strings.Split(path, ".") {
+		if field != nil {
+			typ = field.Type()
+		}
+		if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+
+		str, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			return nil, 0
+		}
+
+		field = nil
+		for i := 0; i < str.NumFields(); i++ {
+			if str.Field(i).Name() == name {
+				field, index = str.Field(i), i
+				break
+			}
+		}
+		if field == nil {
+			return nil, 0
+		}
+	}
+	return field, index
+}
+
+// splitKey returns the parent of a key and its last segment
+func splitKey(key string) (string, string) {
+	i := strings.LastIndex(key, ".")
+	return key[:i+1], key[i+1:]
+}
+
+// normalizeKey returns the key in lower case without separators
+func normalizeKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '.':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, key)
+}
+
+// similarKeyNames returns true if the two key names differ by a single typo. Short names and names ending
+// with a digit, such as numbered keys, are not considered as typos.
+func similarKeyNames(a, b string) bool {
+	if len(a) < minTypoLength || len(b) < minTypoLength || endsWithDigit(a) || endsWithDigit(b) {
+		return false
+	}
+	return editDistance(normalizeKey(a), normalizeKey(b)) == 1
+}
+
+// minTypoLength is the length of the shortest words for which typos are detected
+const minTypoLength = 5
+
+func endsWithDigit(s string) bool {
+	return s != "" && unicode.IsDigit(rune(s[len(s)-1]))
+}
+
+// closestWord returns the candidate closest to the given word, if it is close enough to be a likely typo.
+// Ties are resolved by choosing the first candidate in alphabetical order.
+func closestWord(word string, candidates []string) (string, bool) {
+	sort.Strings(candidates)
+
+	maxDistance := max(1, len(word)/3)
+	var closest string
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(word), strings.ToLower(candidate))
+		if distance <= maxDistance {
+			closest, maxDistance = candidate, distance-1
+		}
+	}
+	return closest, closest != ""
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// mapKeys returns the keys of the given map
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
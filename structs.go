@@ -7,9 +7,12 @@ import (
 	"go/token"
 	"go/types"
 	"math"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/fatih/structtag"
+	"github.com/synthesio/zconfig/v2"
 )
 
 const (
@@ -21,9 +24,11 @@ const (
 )
 
 type structFact struct {
-	Issues   []string
+	Issues   []Issue
 	InitPath string
 	InitPos  token.Pos
+	Schema   Schema
+	Scope    scopeFact
 }
 
 func (structFact) AFact() {}
@@ -86,13 +91,14 @@ func (c *checker) checkStructs() {
 		case *ast.TypeSpec:
 			obj := c.Pass.TypesInfo.ObjectOf(n.Name)
 
-			issues := c.checkStruct(obj.Type(), obj)
+			// alias declarations (e.g. type MyAlias = MyType) declare the type they refer to
+			issues := c.checkStruct(types.Unalias(obj.Type()), obj, n.Pos())
 
 			// do not report issues on struct fields if this is an alias (e.g. type MyType MyOtherType)
 			// this ensures that the same issues are not reported more than once
 			rhsType := c.Pass.TypesInfo.TypeOf(n.Type)
 			if _, ok := rhsType.(*types.Struct); ok {
-				issues.Report(c.Pass)
+				c.reportIssues(issues)
 			}
 		// this is necessary to catch anonymous struct declarations
 		case *ast.StructType:
@@ -105,22 +111,23 @@ func (c *checker) checkStructs() {
 				return
 			}
 
-			issues := c.checkStruct(typ, nil)
-			issues.Report(c.Pass)
+			issues := c.checkStruct(typ, nil, n.Pos())
+			c.reportIssues(issues)
 		}
 	})
 }
 
-// checkStruct returns any detected issues with the given Type.
+// checkStruct returns any detected issues with the given Type, declared at the given position.
 // If the argument is not a struct, then no issue is returned.
 // All struct types are also registered in the PkgStruct map.
-func (c *checker) checkStruct(typ types.Type, obj types.Object) Issues {
+func (c *checker) checkStruct(typ types.Type, obj types.Object, pos token.Pos) Issues {
 	str, ok := typ.Underlying().(*types.Struct)
 	if !ok {
 		return nil
 	}
 
 	info := c.parseStruct(str, typ, nil)
+	info.DeclPos = pos
 
 	// This is done outside the recursive parseStruct method because
 	// we only want to report issues with the Init method of the root struct.
@@ -130,7 +137,7 @@ func (c *checker) checkStruct(typ types.Type, obj types.Object) Issues {
 	c.PkgStructs[typ] = info
 	c.PkgStructs[typ.Underlying()] = info
 	if obj != nil {
-		c.Pass.ExportObjectFact(obj, info.Fact())
+		c.Pass.ExportObjectFact(obj, c.structFact(info))
 	}
 
 	// we have a fully built scope for this struct, so we can check any issues
@@ -159,7 +166,8 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 
 		if !strField.Exported() {
 			if rawTags != "" {
-				info.Issues.Add(strField.Pos(), "private fields cannot have tags")
+				info.Issues.Add(strField.Pos(), newIssue(CheckPrivateTag, "private fields cannot have tags").
+					WithFixes(c.exportFieldFix(strField, typ)...))
 			}
 			continue
 		}
@@ -172,22 +180,30 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 
 		if key, ok := tags[keyTag]; ok {
 			field.Key = key
+			field.Default, field.HasDefault = tags[defaultTag]
+			field.Description = tags[descriptionTag]
+			info.Issues.Add(strField.Pos(), checkKeyName(field)...)
 			if _, ok := tags[injectTag]; ok {
-				info.Issues.Add(strField.Pos(), "key and inject tags should not be used on the same field")
+				// zconfig does not read keys of injection targets
+				info.Issues.Add(strField.Pos(), newIssue(CheckTagConflict, "key and inject tags should not be used on the same field").
+					WithFixes(c.removeTagsFix(strField.Pos(), keyTag, defaultTag, descriptionTag)...))
 			}
 		} else {
+			fixes := c.removeTagsFix(strField.Pos(), defaultTag, descriptionTag)
 			if _, ok := tags[defaultTag]; ok {
-				info.Issues.Add(strField.Pos(), "default tag is used on field without key tag")
+				info.Issues.Add(strField.Pos(), newIssue(CheckOrphanTag, "default tag is used on field without key tag").
+					WithFixes(fixes...))
 			}
 
 			if _, ok := tags[descriptionTag]; ok {
-				info.Issues.Add(strField.Pos(), "description tag is used on field without key tag")
+				info.Issues.Add(strField.Pos(), newIssue(CheckOrphanTag, "description tag is used on field without key tag").
+					WithFixes(fixes...))
 			}
 		}
 
 		if injectAs, ok := tags[injectAsTag]; ok {
 			if !field.IsPointer && !field.IsGeneric {
-				info.Issues.Add(strField.Pos(), "field type is not a pointer, cannot be used as injection source")
+				info.Issues.Add(strField.Pos(), newIssue(CheckInjectionType, "field type is not a pointer, cannot be used as injection source"))
 			}
 
 			field.Alias = injectAs
@@ -196,12 +212,14 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 
 		if inject, ok := tags[injectTag]; ok {
 			if !field.IsPointer && !field.IsInterface {
-				info.Issues.Add(strField.Pos(), "field type is not a pointer nor interface, cannot be used as injection target")
+				info.Issues.Add(strField.Pos(), newIssue(CheckInjectionType, "field type is not a pointer nor interface, cannot be used as injection target"))
 			}
 
 			if field.IsSource {
 				field.IsSource = false
-				info.Issues.Add(strField.Pos(), "inject and inject-as tags cannot be used on the same field")
+				// zconfig considers the field as an injection target, its inject-as tag is ignored
+				info.Issues.Add(strField.Pos(), newIssue(CheckTagConflict, "inject and inject-as tags cannot be used on the same field").
+					WithFixes(c.removeTagsFix(strField.Pos(), injectAsTag)...))
 			}
 
 			field.Alias = inject
@@ -215,6 +233,9 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 
 		if !field.IsStruct() {
 			if field.Key != "" {
+				info.Issues.Add(strField.Pos(), checkLeaf(field)...)
+				info.Issues.Add(strField.Pos(), checkKeyPath(field)...)
+				info.Issues.Add(strField.Pos(), checkLeafKeySyntax(field)...)
 				info.Scope.AddKey(field)
 			}
 
@@ -229,6 +250,9 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 		}
 
 		fieldInfo := c.parseStruct(field.Struct, field.StructType, set)
+		if scope, ok := c.importedScope(field.StructType); ok {
+			fieldInfo.Scope = scope
+		}
 		child := ChildInfo{
 			StructField: field,
 			StructInfo:  fieldInfo,
@@ -239,28 +263,64 @@ func (c *checker) parseStruct(str *types.Struct, typ types.Type, set TypeSet) St
 		if field.Key != "" && len(fieldInfo.Scope.Keys) == 0 {
 			// this struct has an associated key tag, and it has no tagged fields
 			// zconfig will consider it as a leaf, so we can add its key
+			info.Issues.Add(strField.Pos(), checkLeaf(field)...)
+			info.Issues.Add(strField.Pos(), checkKeyPath(field)...)
+			info.Issues.Add(strField.Pos(), checkLeafKeySyntax(field)...)
 			info.Scope.AddKey(field)
 		}
 
 		for _, issues := range fieldInfo.Issues {
-			info.Issues.Add(strField.Pos(), issues...)
+			for _, issue := range issues {
+				info.Issues.Add(strField.Pos(), issue.WithoutFixes())
+			}
 		}
 
 		info.MergeScopes(child)
 	}
 
 	info.resolveInit(typ, c.Pass.Pkg)
+	for i, issue := range info.InitIssues[info.InitPos] {
+		if issue.Check == CheckInitReceiver {
+			info.InitIssues[info.InitPos][i] = issue.WithFixes(c.pointerReceiverFix(info.InitPos)...)
+		}
+	}
+
+	// Silenced issues are removed right away, so that they are neither reported on
+	// the fields of embedding structs nor stored in facts
+	info.Issues = c.withoutIgnored(info.Issues)
+	info.InitIssues = c.withoutIgnored(info.InitIssues)
 
 	return info
 }
 
-func parseTags(rawTags string) (map[string]string, []string) {
+// importedScope returns the scope of a struct declared in another package, as exported in its fact and resolved
+// with the fields of the given type, so that the type arguments of instantiated generic structs are used.
+// False is returned when the struct is declared in the current package or when it has no fact.
+func (c *checker) importedScope(typ types.Type) (Scope, bool) {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == c.Pass.Pkg {
+		return Scope{}, false
+	}
+
+	var fact structFact
+	if !c.Pass.ImportObjectFact(named.Obj(), &fact) {
+		return Scope{}, false
+	}
+	return fact.Scope.Resolve(named), true
+}
+
+// structFact returns the fact exported for the given struct, without any silenced issue
+func (c *checker) structFact(info StructInfo) *structFact {
+	return info.Fact(c.position, c.withoutIgnored(info.FactIssues()))
+}
+
+func parseTags(rawTags string) (map[string]string, []Issue) {
 	parsed, err := structtag.Parse(rawTags)
 	if err != nil {
-		return nil, []string{err.Error()}
+		return nil, []Issue{newIssue(CheckTagSyntax, "%s", err)}
 	}
 
-	var issues []string
+	var issues []Issue
 	tags := make(map[string]string)
 
 	for _, key := range []string{keyTag, descriptionTag, injectTag, injectAsTag} {
@@ -270,27 +330,74 @@ func parseTags(rawTags string) (map[string]string, []string) {
 		}
 
 		if tag.Name == "" {
-			issues = append(issues, fmt.Sprintf("%s tag cannot be empty", key))
+			issues = append(issues, newIssue(CheckEmptyTag, "%s tag cannot be empty", key))
 			continue
 		}
 
 		tags[key] = tag.Name
+		if key == keyTag {
+			issues = append(issues, checkKeySyntax(tag.Name)...)
+		}
 	}
 
+	// default values may contain commas, which structtag considers as options separators
 	tag, err := parsed.Get(defaultTag)
 	if err == nil {
-		tags[defaultTag] = tag.Name
+		tags[defaultTag] = tag.Value()
 	}
 
 	return tags, issues
 }
 
+// envNameRegexp matches the environment variable names which can be set by POSIX shells
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// checkKeySyntax returns the issues of a key which cannot be looked up by the args or env providers of zconfig,
+// or whose dots produce empty segments once joined with the keys of parent structs
+func checkKeySyntax(key string) []Issue {
+	var issues []Issue
+
+	if strings.Contains(key, "=") || strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+		issues = append(issues, newIssue(CheckKeySyntax,
+			"key '%s' cannot be looked up by the args provider, because '=' and whitespace separate --key=value arguments", key))
+	}
+
+	if env := (zconfig.EnvProvider{}).FormatKey(key); !envNameRegexp.MatchString(env) {
+		issues = append(issues, newIssue(CheckKeySyntax,
+			"key '%s' cannot be looked up by the env provider, because '%s' is not a valid environment variable name", key, env))
+	}
+
+	if hasEmptySegment(key) {
+		issues = append(issues, newIssue(CheckKeySyntax,
+			"key '%s' has an empty segment, because it starts or ends with a dot or contains consecutive dots", key))
+	}
+
+	return issues
+}
+
+// checkLeafKeySyntax returns an issue if the key of a leaf field contains dots, which separate the keys of nested
+// structs. Such keys collide with the keys of nested structs.
+func checkLeafKeySyntax(field StructField) []Issue {
+	if !strings.Contains(field.Key, ".") || hasEmptySegment(field.Key) {
+		return nil
+	}
+
+	return []Issue{newIssue(CheckKeySyntax,
+		"key '%s' of field %s contains dots, which only separate the keys of nested structs", field.Key, field.Path)}
+}
+
+// hasEmptySegment returns true if the key starts or ends with a dot, or contains consecutive dots
+func hasEmptySegment(key string) bool {
+	return strings.HasPrefix(key, ".") || strings.HasSuffix(key, ".") || strings.Contains(key, "..")
+}
+
 type ChildInfo struct {
 	StructField
 	StructInfo
 }
 
 type StructInfo struct {
+	DeclPos          token.Pos
 	Children         []ChildInfo
 	Issues           Issues
 	Scope            Scope
@@ -308,29 +415,54 @@ func (s StructInfo) HasInit() bool {
 	return s.InitPos != token.NoPos
 }
 
-// Fact converts StructInfo into a structFact. StructInfo does not implement
-// the Fact interface because it has references to types which cannot be encoded by
-// the gob package, leading to issues with golangci-lint cache.
-func (s StructInfo) Fact() *structFact {
-	var issues []string
+// FactIssues returns all issues which are reported when the struct is used as configuration root.
+func (s StructInfo) FactIssues() Issues {
+	issues := make(Issues)
 	for alias, paths := range s.Scope.UnresolvedTargets() {
-		issues = append(issues, fmt.Sprintf(
+		issue := newIssue(CheckUnresolvedAlias,
 			"no source is provided for alias '%s' used by target fields: %s",
+			alias, strings.Join(paths, ", "))
+
+		if suggestion, ok := closestWord(alias, mapKeys(s.Scope.Sources)); ok {
+			source := s.Scope.Sources[suggestion][0]
+			issue.Message += fmt.Sprintf(", did you mean '%s'?", suggestion)
+			issue = issue.WithRelated(source.Pos, "alias '%s' is provided by source field %s", suggestion, source.Path)
+		}
+		issues.Add(s.Scope.Targets[alias][0].Pos, issue)
+	}
+
+	for alias, paths := range s.Scope.UnusedSources() {
+		issues.Add(s.Scope.Sources[alias][0].Pos, newIssue(CheckUnusedSource,
+			"no target is injected with alias '%s' provided by source fields: %s",
 			alias, strings.Join(paths, ", ")))
 	}
 
 	for _, cycle := range s.DependencyCycles {
-		issues = append(issues, fmt.Sprintf("configured struct contains dependency cycle: %s", cycle))
+		issues.Add(s.DeclPos, newIssue(CheckDependencyCycle, "configured struct contains dependency cycle: %s", cycle))
 	}
 
-	for _, fieldIssues := range s.Issues {
-		issues = append(issues, fieldIssues...)
+	return issues.Merge(s.Issues)
+}
+
+// Fact converts StructInfo into a structFact containing the given issues. StructInfo does not implement
+// the Fact interface because it has references to types which cannot be encoded by
+// the gob package, leading to issues with golangci-lint cache.
+// Issues keep the position where they were detected, converted with the given function.
+func (s StructInfo) Fact(position func(token.Pos) Position, factIssues Issues) *structFact {
+	var issues []Issue
+	for _, pos := range factIssues.Positions() {
+		for _, issue := range factIssues[pos] {
+			issue.Position = position(pos)
+			issues = append(issues, issue.WithoutFixes())
+		}
 	}
 
 	return &structFact{
 		Issues:   issues,
 		InitPath: s.InitPath,
 		InitPos:  s.InitPos,
+		Schema:   s.Schema(),
+		Scope:    s.Scope.Fact(position),
 	}
 }
 
@@ -345,18 +477,20 @@ func (s *StructInfo) MergeScopes(child ChildInfo) {
 		for _, keyFields := range o.Keys {
 			for _, keyField := range keyFields {
 				keyField.Path = field.Path + "." + keyField.Path
+				keyField.Pos = field.Pos
 				if field.Key != "" {
 					keyField.Key = field.Key + "." + keyField.Key
+					// keys of embedded structs are unchanged, so they were already checked in the child scope
+					s.Issues.Add(field.Pos, checkKeyPath(keyField)...)
 				}
 
-				keyField.Pos = field.Pos
 				s.Scope.AddKey(keyField)
 			}
 		}
 	}
 
 	if !mergeKeys && len(o.Keys) > 0 {
-		s.Issues.Add(field.Pos, fmt.Sprintf("field %s contains key tags but is not tagged with a key", field.Path))
+		s.Issues.Add(field.Pos, newIssue(CheckMissingKey, "field %s contains key tags but is not tagged with a key", field.Path))
 	}
 
 	for _, sourceFields := range o.Sources {
@@ -413,11 +547,12 @@ func lookupInitMethod(typ types.Type, pkg *types.Package) (token.Pos, bool) {
 }
 
 func sameNamedType(t1, t2 types.Type) bool {
+	t1, t2 = types.Unalias(t1), types.Unalias(t2)
 	if ptr, ok := t1.(*types.Pointer); ok {
-		t1 = ptr.Elem()
+		t1 = types.Unalias(ptr.Elem())
 	}
 	if ptr, ok := t2.(*types.Pointer); ok {
-		t2 = ptr.Elem()
+		t2 = types.Unalias(ptr.Elem())
 	}
 
 	named1, ok := t1.(*types.Named)
@@ -447,7 +582,7 @@ func (s *StructInfo) resolveInit(typ types.Type, pkg *types.Package) {
 		if !isPtr {
 			// if the Init method is not implemented on a pointer receiver, then it will always be called
 			s.CallCount = 1
-			s.InitIssues.Add(pos, "Init method is not declared on pointer receiver")
+			s.InitIssues.Add(pos, newIssue(CheckInitReceiver, "Init method is not declared on pointer receiver"))
 		}
 	}
 
@@ -467,7 +602,7 @@ func (s *StructInfo) resolveInit(typ types.Type, pkg *types.Package) {
 		if len(child.InitIssues) > 0 {
 			// Report a generic message on the field if it has Init issues. Detailed issues will already be
 			// reported on that struct fields.
-			s.InitIssues.Add(child.Pos, fmt.Sprintf(
+			s.InitIssues.Add(child.Pos, newIssue(CheckInitNested,
 				"type %s has one or more issues with Init methods implemented by itself, embedded structs or its fields",
 				child.StructType.String(),
 			))
@@ -488,7 +623,7 @@ func (s *StructInfo) resolveInit(typ types.Type, pkg *types.Package) {
 			// If this is not an embedded field, then zconfig cannot have access to a pointer so its Init method
 			// won't be called
 			if child.CallCount == 0 {
-				s.InitIssues.Add(child.Pos, fmt.Sprintf("Init method of %s won't be called", path))
+				s.InitIssues.Add(child.Pos, newIssue(CheckInitNotCalled, "Init method of %s won't be called", path))
 			}
 
 			continue
@@ -533,7 +668,7 @@ func (s *StructInfo) resolveInit(typ types.Type, pkg *types.Package) {
 			s.CallCount = e.CallCount + 1
 		}
 		if s.CallCount > 1 {
-			s.InitIssues.Add(e.Pos, fmt.Sprintf("Init method of %s will be called %d times", s.InitPath, s.CallCount))
+			s.InitIssues.Add(e.Pos, newIssue(CheckInitMultiCall, "Init method of %s will be called %d times", s.InitPath, s.CallCount))
 		}
 	}
 
@@ -541,7 +676,7 @@ func (s *StructInfo) resolveInit(typ types.Type, pkg *types.Package) {
 		// The Init method implemented by the embedded struct is not inherited by the embedding one.
 		// Since we know it cannot be called on the embedded field, then we know it will never be called
 		if i != minDepthIndex && e.CallCount == 0 {
-			s.InitIssues.Add(e.Pos, fmt.Sprintf("Init method of %s won't be called", e.InitPath))
+			s.InitIssues.Add(e.Pos, newIssue(CheckInitNotCalled, "Init method of %s won't be called", e.InitPath))
 		}
 	}
 }
@@ -559,11 +694,14 @@ type StructField struct {
 	IsPointer   bool
 	IsInterface bool
 
-	Key      string
-	Alias    string
-	IsSource bool
-	IsTarget bool
-	Path     string
+	Key         string
+	Default     string
+	HasDefault  bool
+	Description string
+	Alias       string
+	IsSource    bool
+	IsTarget    bool
+	Path        string
 }
 
 func (s StructField) String() string {
@@ -577,29 +715,114 @@ func (s StructField) String() string {
 // - field b is of type T[constraints.Integer]
 // They are compatible, because they can both be assigned an int type
 func (s StructField) CompatibleWith(f StructField) bool {
+	switch {
+	case s.IsGeneric && !f.IsGeneric:
+		return s.instantiableWith(f.typeOrConstraint)
+	case f.IsGeneric && !s.IsGeneric:
+		return f.instantiableWith(s.typeOrConstraint)
+	}
+
 	return types.AssignableTo(s.typeOrConstraint, f.typeOrConstraint) ||
 		types.AssignableTo(f.typeOrConstraint, s.typeOrConstraint)
 }
 
 // AssignableTo returns true if the method receiver can be assigned to the dest argument.
 // If the method receiver is a generic type, then we consider it assignable to avoid
-// raising potentially false issues.
+// raising potentially false issues. This only happens in generic struct declarations,
+// since fields of instantiated structs have the types of the type arguments.
 func (s StructField) AssignableTo(dest StructField) bool {
-	if s.IsGeneric && !dest.IsGeneric {
+	switch {
+	case s.IsGeneric && !dest.IsGeneric:
 		return true
+	case dest.IsGeneric && !s.IsGeneric:
+		return dest.instantiableWith(s.typeOrConstraint)
 	}
 	return types.AssignableTo(s.typeOrConstraint, dest.typeOrConstraint)
 }
 
+// instantiableWith returns true if the generic field can have the given type once its struct is instantiated.
+// For example, a field of type *T can have the type *int if int satisfies the constraint of T.
+func (s StructField) instantiableWith(typ types.Type) bool {
+	if s.IsPointer {
+		ptr, ok := typ.Underlying().(*types.Pointer)
+		if !ok {
+			return false
+		}
+		typ = ptr.Elem()
+	}
+
+	constraint, ok := s.typeOrConstraint.Underlying().(*types.Interface)
+	if !ok {
+		return true
+	}
+	return types.Satisfies(typ, constraint)
+}
+
+// mismatchReason explains why the method receiver cannot be assigned to the dest argument when dest is an interface,
+// by listing the methods it misses. An empty string is returned if there is nothing to explain.
+func (s StructField) mismatchReason(dest StructField) string {
+	iface, ok := dest.typeOrConstraint.Underlying().(*types.Interface)
+	if !ok || dest.IsGeneric || s.IsGeneric {
+		return ""
+	}
+
+	var missing, wrongType, pointerReceiver []string
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		single := types.NewInterfaceType([]*types.Func{method}, nil).Complete()
+
+		m, wrong := types.MissingMethod(s.typeOrConstraint, single, true)
+		switch {
+		case m == nil:
+			continue
+		case !s.IsPointer && !isMissingMethod(types.NewPointer(s.typeOrConstraint), single):
+			pointerReceiver = append(pointerReceiver, method.Name())
+		case wrong:
+			wrongType = append(wrongType, method.Name())
+		default:
+			missing = append(missing, method.Name())
+		}
+	}
+
+	methods := func(names []string) string {
+		if len(names) == 1 {
+			return "method " + names[0]
+		}
+		return "methods " + strings.Join(names, ", ")
+	}
+
+	var reasons []string
+	if len(missing) > 0 {
+		reasons = append(reasons, "missing "+methods(missing))
+	}
+	if len(wrongType) > 0 {
+		reasons = append(reasons, "wrong type for "+methods(wrongType))
+	}
+	if len(pointerReceiver) > 0 {
+		reasons = append(reasons, "pointer receiver for "+methods(pointerReceiver))
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(": %s does not implement %s (%s)", s.typeOrConstraint, dest.typeOrConstraint, strings.Join(reasons, "; "))
+}
+
+// isMissingMethod returns true if the given type does not implement the interface
+func isMissingMethod(typ types.Type, iface *types.Interface) bool {
+	m, _ := types.MissingMethod(typ, iface, true)
+	return m != nil
+}
+
 func (s StructField) IsStruct() bool {
 	return s.Struct != nil
 }
 
 func newStructField(field *types.Var, index int) StructField {
-	typ := field.Type()
+	typ := types.Unalias(field.Type())
 	ptr, isPtr := typ.(*types.Pointer)
 	if isPtr {
-		typ = ptr.Elem()
+		typ = types.Unalias(ptr.Elem())
 	}
 
 	typeParam, _ := typ.(*types.TypeParam)
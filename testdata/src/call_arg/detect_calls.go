@@ -7,12 +7,12 @@ import (
 	"testdata/src/call_arg/subpackage"
 )
 
-func directConfigureCall() {
-	zconfig.Configure(context.Background(), true) // want "argument used as configuration receiver is not a struct pointer"
+func directConfigureCall() error {
+	return zconfig.Configure(context.Background(), true) // want "argument used as configuration receiver is not a struct pointer"
 }
 
-func directProcessCall() {
-	zconfig.DefaultProcessor.Process(context.Background(), true) // want "argument used as configuration receiver is not a struct pointer"
+func directProcessCall() error {
+	return zconfig.DefaultProcessor.Process(context.Background(), true) // want "argument used as configuration receiver is not a struct pointer"
 }
 
 func configureWrapperCall() {
@@ -0,0 +1,50 @@
+package callgraph // want package:"has wrappers"
+
+import (
+	"context"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Target *bool `inject:"missing"`
+}
+
+// Loader configures structs, its implementations are only known at runtime
+type Loader interface {
+	Load(ctx context.Context, s any) error
+}
+
+type loader struct{} // want loader:"<init:none>"
+
+func NewLoader() Loader {
+	return loader{}
+}
+
+func (loader) Load(ctx context.Context, s any) error { // want Load:"wrapper, arg: 2, is method, returns error"
+	return zconfig.Configure(ctx, s)
+}
+
+func LoadInterface(l Loader) error {
+	return l.Load(context.Background(), new(Config)) // want "ZC201: no source is provided for alias 'missing' used by target fields: Target"
+}
+
+// Service stores the function used to configure structs
+type Service struct { // want Service:"<init:none>"
+	Configure func(ctx context.Context, s any) error
+}
+
+func NewService() *Service {
+	return &Service{Configure: zconfig.Configure}
+}
+
+func (s *Service) Run() error {
+	return s.Configure(context.Background(), new(Config)) // want "ZC201: no source is provided for alias 'missing' used by target fields: Target"
+}
+
+func Main() error {
+	if err := LoadInterface(NewLoader()); err != nil {
+		return err
+	}
+	return NewService().Run()
+}
@@ -2,6 +2,7 @@ package cycles
 
 import (
 	"context"
+	"errors"
 
 	"github.com/synthesio/zconfig/v2"
 	"testdata/src/cycles/subpackage"
@@ -25,7 +26,7 @@ type D struct { // want D:"<init:none>"
 	E *bool `inject:"e"`
 }
 
-var _ = zconfig.Configure(context.Background(), new(A)) /* want
+var errA = zconfig.Configure(context.Background(), new(A)) /* want
 "configured struct contains dependency cycle: testdata/src/cycles.A -> testdata/src/cycles.B -> testdata/src/cycles.C -> testdata/src/cycles.A"
 "configured struct contains dependency cycle: testdata/src/cycles.A -> testdata/src/cycles.B -> testdata/src/cycles.A"
 "no source is provided for alias 'e' used by target fields: D.E"
@@ -39,19 +40,19 @@ type F struct { // want F:"<init:none>"
 	*Generic[F]
 }
 
-var _ = zconfig.Configure(context.Background(), new(Generic[F])) /* want
+var errGeneric = zconfig.Configure(context.Background(), new(Generic[F])) /* want
 "configured struct contains dependency cycle: testdata/src/cycles.Generic\\[testdata/src/cycles.F\\] -> testdata/src/cycles.F -> testdata/src/cycles.Generic\\[testdata/src/cycles.F\\]"
 */
 
 type genericF Generic[F] // want genericF:"<init:none>"
 
-var _ = zconfig.Configure(context.Background(), &genericF{}) /* want
+var errGenericF = zconfig.Configure(context.Background(), &genericF{}) /* want
 "configured struct contains dependency cycle: testdata/src/cycles.genericF -> testdata/src/cycles.F -> testdata/src/cycles.Generic\\[testdata/src/cycles.F\\] -> testdata/src/cycles.F"
 */
 
 type genericF2 = Generic[F] // want genericF2:"<init:none>"
 
-var _ = zconfig.Configure(context.Background(), &genericF2{}) /* want
+var errGenericF2 = zconfig.Configure(context.Background(), &genericF2{}) /* want
 "configured struct contains dependency cycle: testdata/src/cycles.Generic\\[testdata/src/cycles.F\\] -> testdata/src/cycles.F -> testdata/src/cycles.Generic\\[testdata/src/cycles.F\\]"
 */
 
@@ -59,6 +60,11 @@ type G struct { // want G:"<init:none>"
 	subpackage.Generic[*G]
 }
 
-var _ = zconfig.Configure(context.Background(), new(G)) /* want
+var errG = zconfig.Configure(context.Background(), new(G)) /* want
 "configured struct contains dependency cycle: testdata/src/cycles.G -> testdata/src/cycles/subpackage.Generic\\[\\*testdata/src/cycles.G\\] -> testdata/src/cycles.G"
 */
+
+// configErrors reads the errors of the configurations, so that they are checked
+func configErrors() error {
+	return errors.Join(errA, errGeneric, errGenericF, errGenericF2, errG)
+}
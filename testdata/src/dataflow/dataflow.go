@@ -0,0 +1,103 @@
+package dataflow
+
+import (
+	"context"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type A struct { // want A:"<init:none>"
+	Target *bool `inject:"a"`
+}
+
+type B struct { // want B:"<init:none>"
+	Target *bool `inject:"b"`
+}
+
+type Valid struct { // want Valid:"<init:none>"
+	Host string `key:"host"`
+}
+
+func rangeOverSlice(ctx context.Context) error {
+	var a A
+	var b B
+	for _, c := range []any{&a, &b} {
+		if err := zconfig.Configure(ctx, c); err != nil { // want "ZC201: no source is provided for alias 'a' used by target fields: Target" "ZC201: no source is provided for alias 'b' used by target fields: Target"
+			return err
+		}
+	}
+	return nil
+}
+
+func rangeOverMap(ctx context.Context) error {
+	configs := map[string]any{
+		"a": new(A),
+		"v": new(Valid),
+	}
+	for _, c := range configs {
+		if err := zconfig.Configure(ctx, c); err != nil { // want "ZC201: no source is provided for alias 'a' used by target fields: Target"
+			return err
+		}
+	}
+	return nil
+}
+
+func mapLookup(ctx context.Context) error {
+	configs := map[string]any{"b": new(B)}
+	return zconfig.Configure(ctx, configs["b"]) // want "ZC201: no source is provided for alias 'b' used by target fields: Target"
+}
+
+func phi(ctx context.Context, useA bool) error {
+	var c any = new(Valid)
+	if useA {
+		c = new(A)
+	}
+	return zconfig.Configure(ctx, c) // want "ZC201: no source is provided for alias 'a' used by target fields: Target"
+}
+
+type holder struct { // want holder:"<init:none>"
+	config any
+}
+
+func structField(ctx context.Context) error {
+	h := &holder{}
+	h.config = new(B)
+	return zconfig.Configure(ctx, h.config) // want "ZC201: no source is provided for alias 'b' used by target fields: Target"
+}
+
+func newA() *A {
+	return &A{}
+}
+
+func newConfig() (any, error) {
+	return new(B), nil
+}
+
+func constructor(ctx context.Context) error {
+	if err := zconfig.Configure(ctx, newA()); err != nil { // want "ZC201: no source is provided for alias 'a' used by target fields: Target"
+		return err
+	}
+
+	c, err := newConfig()
+	if err != nil {
+		return err
+	}
+	return zconfig.Configure(ctx, c) // want "ZC201: no source is provided for alias 'b' used by target fields: Target"
+}
+
+// values stored by closures are not followed
+func capturedVariable(ctx context.Context) error {
+	var c any
+	set := func() { c = new(A) }
+	set()
+	return zconfig.Configure(ctx, c) // want "argument used as configuration receiver is not a struct pointer"
+}
+
+func validRoots(ctx context.Context) error {
+	for _, c := range []any{new(Valid), &Valid{}} {
+		if err := zconfig.Configure(ctx, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
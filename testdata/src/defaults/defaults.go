@@ -0,0 +1,50 @@
+package defaults
+
+import (
+	"log/slog"
+	"net"
+	"net/netip"
+	"regexp"
+	"time"
+)
+
+type Port int
+
+type Defaults struct { // want Defaults:"<init:none>"
+	Timeout  time.Duration  `key:"timeout" default:"5"` // want "default value '5' cannot be parsed as time.Duration: expected a duration with a unit suffix, such as '300ms' or '1h30m'"
+	Timeout2 time.Duration  `key:"timeout2" default:"5s"`
+	Port     int            `key:"port" default:"eighty"` // want "default value 'eighty' cannot be parsed as int: expected an integer between -9223372036854775808 and 9223372036854775807"
+	Port2    *int           `key:"port2" default:"80"`
+	Small    int8           `key:"small" default:"128"`   // want "default value '128' cannot be parsed as int8: expected an integer between -128 and 127"
+	Unsigned uint16         `key:"unsigned" default:"-1"` // want "default value '-1' cannot be parsed as uint16: expected an unsigned integer between 0 and 65535"
+	Ratio    float64        `key:"ratio" default:"0.5"`
+	Ratio2   float32        `key:"ratio2" default:"half"` // want "default value 'half' cannot be parsed as float32: expected a floating point number"
+	Enabled  bool           `key:"enabled" default:"yes"` // want "default value 'yes' cannot be parsed as bool: expected a boolean such as 'true', 'false', '1' or '0'"
+	Enabled2 bool           `key:"enabled2" default:"1"`
+	Name     string         `key:"name" default:""`
+	Names    []string       `key:"names" default:"a, b,c"`
+	IDs      []int          `key:"ids" default:"1, 2,,3"`
+	IDs2     []int64        `key:"ids2" default:"1,two"`   // want "default value '1,two' cannot be parsed as \\[\\]int64: expected a comma-separated list of integers"
+	Pattern  regexp.Regexp  `key:"pattern" default:"[a-z"` // want "default value '\\[a-z' cannot be parsed as regexp.Regexp: expected a valid regular expression"
+	Pattern2 *regexp.Regexp `key:"pattern2" default:"^[a-z]+$"`
+
+	// standard library encoding.TextUnmarshaler implementations are checked
+	IP  net.IP     `key:"ip" default:"not an ip"` // want "default value 'not an ip' cannot be parsed as net.IP: expected an IPv4 or IPv6 address"
+	IP2 netip.Addr `key:"ip2" default:"::1"`
+
+	// the empty string is the zero value of net.IP and net/netip types
+	IP3      net.IP         `key:"ip3" default:""`
+	IP4      netip.Addr     `key:"ip4" default:""`
+	AddrPort netip.AddrPort `key:"addr-port" default:""`
+	Prefix   netip.Prefix   `key:"prefix" default:""`
+	Prefix2  netip.Prefix   `key:"prefix2" default:"10.0.0.0"` // want "default value '10.0.0.0' cannot be parsed as net/netip.Prefix: expected an IP network prefix in CIDR notation, such as '10.0.0.0/8'"
+
+	// other encoding.TextUnmarshaler implementations cannot be checked
+	Level slog.Level `key:"level" default:"verbose"`
+
+	// zconfig does not parse named types unless they implement encoding.TextUnmarshaler, so their defaults are not checked
+	CustomPort Port `key:"custom-port" default:"eighty"` // want "type testdata/src/defaults.Port is not handled by zconfig default parsers, configuring field CustomPort will fail"
+
+	// default tags are only used on keyed fields
+	NoKey int `default:"eighty"` // want "default tag is used on field without key tag"
+}
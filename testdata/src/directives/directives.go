@@ -0,0 +1,58 @@
+package directives
+
+import (
+	"context"
+	"errors"
+
+	"github.com/synthesio/zconfig/v2"
+
+	"testdata/src/directives/subpackage"
+)
+
+type Fields struct { // want Fields:"<init:none>"
+	A bool `key:"a"` //zconfigcheck:ignore duplicate-key kept for backward compatibility
+	B bool `key:"a"` // want "ZC101: key 'a' defined by field 'B' is already used by field 'A'"
+
+	// Directives can be placed on the line before the field, and list codes
+	//zconfigcheck:ignore ZC104,ZC101 legacy value
+	C int `key:"c" default:"c"`
+	D int `key:"c"` // want "ZC101: key 'c' defined by field 'D' is already used by field 'C'"
+}
+
+// Only the listed checks are silenced
+type Partial struct { // want Partial:"<init:none>"
+	A int `key:"a" default:"a"` //zconfigcheck:ignore duplicate-key wrong check // want "ZC104: default value 'a' cannot be parsed as int" "ZC902: unused //zconfigcheck:ignore directive for duplicate-key"
+}
+
+// Directives placed before a declaration apply to the whole declaration
+//
+//zconfigcheck:ignore env-collision keys are only read from arguments
+type Declaration struct { // want Declaration:"<init:none>"
+	A bool `key:"a_b"`
+	B bool `key:"a-b"`
+}
+
+// Silenced issues are not reported on embedding structs nor at configuration calls
+type Nested struct { // want Nested:"<init:none>"
+	Fields Fields `key:"fields"`
+}
+
+type Unresolved struct { // want Unresolved:"<init:none>"
+	Target *bool `inject:"source"`
+}
+
+func Configure() error {
+	return errors.Join(
+		zconfig.Configure(context.Background(), new(Nested)),
+
+		// Issues silenced in another package are not reported
+		zconfig.Configure(context.Background(), new(subpackage.Config)),
+
+		//zconfigcheck:ignore unresolved-alias the source is injected by another call
+		zconfig.Configure(context.Background(), new(Unresolved)),
+		zconfig.Configure(context.Background(), new(Unresolved)), // want "ZC201: no source is provided for alias 'source' used by target fields: Target"
+	)
+}
+
+//zconfigcheck:ignore unknown-check reason // want "ZC901: malformed directive: unknown check unknown-check"
+//zconfigcheck:ignored is another directive
@@ -0,0 +1,7 @@
+package subpackage
+
+// Issues silenced where the struct is declared are not reported where it is configured
+type Config struct {
+	//zconfigcheck:ignore unresolved-alias the source is provided by the embedding structs
+	Target *bool `inject:"source"`
+}
@@ -0,0 +1,23 @@
+package disabled
+
+// Issues of disabled checks are not reported
+type Disabled struct { // want Disabled:"<init:own>"
+	Field1 bool `key:"Field"`
+	Field2 bool `key:"field"`
+
+	Field *Init
+}
+
+func (d *Disabled) Init() error {
+	return d.Field.Init()
+}
+
+type Init struct{} // want Init:"<init:own>"
+
+func (*Init) Init() error { return nil }
+
+// Issues of other checks are still reported
+type Enabled struct { // want Enabled:"<init:none>"
+	A bool `key:"a"` // want "ZC101: key 'a' defined by field 'A' is already used by field 'B'"
+	B bool `key:"a"` // want "ZC101: key 'a' defined by field 'B' is already used by field 'A'"
+}
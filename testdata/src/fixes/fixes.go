@@ -0,0 +1,32 @@
+package fixes
+
+type Tags struct { // want Tags:"<init:none>"
+	NoKey   int  `default:"1"`                        // want "default tag is used on field without key tag"
+	NoKey2  int  `default:"1" description:"desc"`     // want "default tag is used on field without key tag" "description tag is used on field without key tag"
+	NoKey3  int  `json:"no_key" default:"1"`          // want "default tag is used on field without key tag"
+	Target  *int `key:"target" inject:"target"`       // want "key and inject tags should not be used on the same field"
+	Target2 *int `inject:"target" inject-as:"source"` // want "inject and inject-as tags cannot be used on the same field"
+	Source  *int `inject-as:"target"`
+}
+
+type Private struct { // want Private:"<init:none>"
+	private bool `key:"private"` // want "private fields cannot have tags"
+
+	// fields cannot be renamed when the exported name is already used
+	conflict bool `key:"conflict"` // want "private fields cannot have tags"
+	Conflict bool
+}
+
+func (p Private) IsPrivate() bool {
+	return p.private
+}
+
+func NewPrivate() Private {
+	return Private{private: true}
+}
+
+type Receiver struct{} // want Receiver:"<init:own>"
+
+func (r Receiver) Init() error { // want "Init method is not declared on pointer receiver"
+	return nil
+}
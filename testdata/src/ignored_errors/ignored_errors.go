@@ -0,0 +1,106 @@
+package ignored_errors // want package:"has wrappers"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Host string `key:"host"`
+}
+
+var _ = zconfig.Configure(context.Background(), new(Config)) // want "ZC502: error returned by Configure is not checked"
+
+var errUnread = zconfig.Configure(context.Background(), new(Config)) // want "ZC502: error returned by Configure is not checked"
+
+var errRead = zconfig.Configure(context.Background(), new(Config))
+
+func readError() error {
+	return errRead
+}
+
+func discarded(ctx context.Context) {
+	zconfig.Configure(ctx, new(Config))                    // want "ZC502: error returned by Configure is not checked"
+	_ = zconfig.Configure(ctx, new(Config))                // want "ZC502: error returned by Configure is not checked"
+	_ = zconfig.DefaultProcessor.Process(ctx, new(Config)) // want "ZC502: error returned by Process is not checked"
+	defer zconfig.Configure(ctx, new(Config))              // want "ZC502: error returned by Configure is not checked"
+	go zconfig.Configure(ctx, new(Config))                 // want "ZC502: error returned by Configure is not checked"
+}
+
+func checked(ctx context.Context) error {
+	if err := zconfig.Configure(ctx, new(Config)); err != nil {
+		return err
+	}
+
+	err := zconfig.DefaultProcessor.Process(ctx, new(Config))
+	return err
+}
+
+// Wrapper returns the error of zconfig, its callers must check it
+func Wrapper(ctx context.Context, s any) error { // want Wrapper:"wrapper, arg: 1, returns error"
+	if err := zconfig.Configure(ctx, s); err != nil {
+		return fmt.Errorf("configuring: %w", err)
+	}
+	return nil
+}
+
+// MustConfigure handles the error of zconfig itself
+func MustConfigure(ctx context.Context, s any) { // want MustConfigure:"wrapper, arg: 1"
+	if err := zconfig.Configure(ctx, s); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Discarding drops the error of zconfig, its callers cannot check it
+func Discarding(ctx context.Context, s any) error { // want Discarding:"wrapper, arg: 1"
+	_ = zconfig.Configure(ctx, s) // want "ZC502: error returned by Configure is not checked"
+	return nil
+}
+
+// JoiningWrapper returns the error of zconfig joined with another one, its callers must check it
+func JoiningWrapper(ctx context.Context, s any) (err error) { // want JoiningWrapper:"wrapper, arg: 1, returns error"
+	err = zconfig.Configure(ctx, s)
+	return errors.Join(err, ctx.Err())
+}
+
+// HandlingWrapper handles the error of zconfig itself and returns nil
+func HandlingWrapper(ctx context.Context, s any) error { // want HandlingWrapper:"wrapper, arg: 1"
+	if err := zconfig.Configure(ctx, s); err != nil {
+		log.Print(err)
+	}
+	return nil
+}
+
+// UnrelatedWrapper handles the error of zconfig itself and returns another error
+func UnrelatedWrapper(ctx context.Context, s any) error { // want UnrelatedWrapper:"wrapper, arg: 1"
+	if err := zconfig.Configure(ctx, s); err != nil {
+		log.Print(err)
+	}
+	return ctx.Err()
+}
+
+// NestedWrapper returns the error of another wrapper
+func NestedWrapper(s any) error { // want NestedWrapper:"wrapper, arg: 0, returns error"
+	return Wrapper(context.Background(), s)
+}
+
+func wrapperCalls(ctx context.Context) error {
+	Wrapper(ctx, new(Config))      // want "ZC502: error returned by Wrapper is not checked"
+	_ = NestedWrapper(new(Config)) // want "ZC502: error returned by NestedWrapper is not checked"
+	MustConfigure(ctx, new(Config))
+	JoiningWrapper(ctx, new(Config)) // want "ZC502: error returned by JoiningWrapper is not checked"
+	HandlingWrapper(ctx, new(Config))
+	_ = UnrelatedWrapper(ctx, new(Config))
+	_ = Discarding(ctx, new(Config))
+
+	configure := func(s any) error {
+		return zconfig.Configure(ctx, s)
+	}
+	configure(new(Config)) // want "ZC502: error returned by anonymous function is not checked"
+
+	return NestedWrapper(new(Config))
+}
@@ -69,7 +69,11 @@ type MissingSource struct { // want MissingSource:"<init:none>"
 	A GenericTarget[*bool]
 }
 
-var _ = zconfig.Configure(context.Background(), new(MissingSource)) // want "no source is provided for alias 't' used by target fields: A.Target"
+var errMissingSource = zconfig.Configure(context.Background(), new(MissingSource)) // want "no source is provided for alias 't' used by target fields: A.Target"
+
+func missingSourceError() error {
+	return errMissingSource
+}
 
 type InjectFromGeneric struct { // want InjectFromGeneric:"<init:none>"
 	A GenericSource[struct { /* want
@@ -86,3 +90,19 @@ type InjectFromGeneric struct { // want InjectFromGeneric:"<init:none>"
 		Target *string `inject:"source"`
 	}]
 }
+
+type InstantiatedSource struct { // want InstantiatedSource:"<init:none>"
+	GenericSource[*int]         // want "injection alias 't': cannot inject source field 'GenericSource.Source \\*int' into target field 'Target \\*string', mismatched types"
+	Target              *string `inject:"t"` // want "injection alias 't': target field 'Target \\*string' cannot be injected with source field 'GenericSource.Source \\*int', mismatched types"
+}
+
+type PointerTargets[T ~int] struct { // want PointerTargets:"<init:none>"
+	Source  *int    `inject-as:"p"` // want "injection alias 'p': cannot inject source field 'Source \\*int' into target field 'String \\*string', mismatched types"
+	Generic *T      `inject:"p"`    // want "injection alias 'p': target fields 'Generic ~int' and 'String \\*string' are incompatible, mismatched types"
+	Int     *int    `inject:"p"`    // want "injection alias 'p': target fields 'Int \\*int' and 'String \\*string' are incompatible, mismatched types"
+	String  *string `inject:"p"`    /* want
+	"injection alias 'p': target fields 'String \\*string' and 'Generic ~int' are incompatible, mismatched types"
+	"injection alias 'p': target fields 'String \\*string' and 'Int \\*int' are incompatible, mismatched types"
+	"injection alias 'p': target field 'String \\*string' cannot be injected with source field 'Source \\*int', mismatched types"
+	*/
+}
@@ -58,6 +58,15 @@ type missingSources struct { // want missingSources:"<init:none>"
 	Target3 *bool `inject:"target3"`
 }
 
+type unusedSources struct { // want unusedSources:"<init:none>"
+	DB      *bool `inject-as:"db"`
+	Replica *bool `inject-as:"replica"`
+	Target  *bool `inject:"db"`
+	Nested  struct {
+		Cache *bool `inject-as:"cache"`
+	}
+}
+
 var anon struct {
 	Target subpackage.InjectionTarget
 }
@@ -74,11 +83,19 @@ func main() {
 	"no source is provided for alias 'source' used by target fields: Target.Target, Target2.Target"
 	*/
 
+	Configure(context.Background(), new(unusedSources)) /* want
+	"ZC205: no target is injected with alias 'replica' provided by source fields: Replica"
+	"ZC205: no target is injected with alias 'cache' provided by source fields: Nested.Cache"
+	*/
+
 	var missing2 missingSources
-	zconfig.Configure(context.Background(), &missing2) /* want
+	err := zconfig.Configure(context.Background(), &missing2) /* want
 	"no source is provided for alias 'target3' used by target fields: Target3"
 	"no source is provided for alias 'source' used by target fields: Target.Target, Target2.Target"
 	*/
+	if err != nil {
+		panic(err)
+	}
 }
 
 func Configure(ctx context.Context, str any) { // want Configure:""
@@ -87,3 +104,40 @@ func Configure(ctx context.Context, str any) { // want Configure:""
 		panic(err)
 	}
 }
+
+type closer interface {
+	Close() error
+	Name() string
+}
+
+type valueCloser struct{} // want valueCloser:"<init:none>"
+
+func (*valueCloser) Close() error { return nil }
+func (valueCloser) Name() string  { return "" }
+
+type wrongCloser struct{} // want wrongCloser:"<init:none>"
+
+func (*wrongCloser) Close() {}
+
+type interfaceTargets struct { // want interfaceTargets:"<init:none>"
+	Value valueCloser `inject-as:"value"` /* want
+	"field type is not a pointer, cannot be used as injection source"
+	"injection alias 'value': cannot inject source field 'Value testdata/src/injection.valueCloser' into target field 'ValueTarget testdata/src/injection.closer', mismatched types: testdata/src/injection.valueCloser does not implement testdata/src/injection.closer \\(pointer receiver for method Close\\)"
+	*/
+	Wrong *wrongCloser `inject-as:"wrong"` /* want
+	"injection alias 'wrong': cannot inject source field 'Wrong \\*testdata/src/injection.wrongCloser' into target field 'WrongTarget testdata/src/injection.closer', mismatched types: \\*testdata/src/injection.wrongCloser does not implement testdata/src/injection.closer \\(missing method Name; wrong type for method Close\\)"
+	*/
+	Struct *struct{} `inject-as:"struct"` /* want
+	"injection alias 'struct': cannot inject source field 'Struct \\*struct{}' into target field 'StructTarget testdata/src/injection.closer', mismatched types: \\*struct{} does not implement testdata/src/injection.closer \\(missing methods Close, Name\\)"
+	*/
+
+	ValueTarget closer `inject:"value"` /* want
+	"injection alias 'value': target field 'ValueTarget testdata/src/injection.closer' cannot be injected with source field 'Value testdata/src/injection.valueCloser', mismatched types: testdata/src/injection.valueCloser does not implement testdata/src/injection.closer \\(pointer receiver for method Close\\)"
+	*/
+	WrongTarget closer `inject:"wrong"` /* want
+	"injection alias 'wrong': target field 'WrongTarget testdata/src/injection.closer' cannot be injected with source field 'Wrong \\*testdata/src/injection.wrongCloser', mismatched types: \\*testdata/src/injection.wrongCloser does not implement testdata/src/injection.closer \\(missing method Name; wrong type for method Close\\)"
+	*/
+	StructTarget closer `inject:"struct"` /* want
+	"injection alias 'struct': target field 'StructTarget testdata/src/injection.closer' cannot be injected with source field 'Struct \\*struct{}', mismatched types: \\*struct{} does not implement testdata/src/injection.closer \\(missing methods Close, Name\\)"
+	*/
+}
@@ -0,0 +1,71 @@
+package multiple_configure // want package:"has wrappers"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Host string `key:"host"`
+}
+
+var global Config
+
+func configure(ctx context.Context, s any) error { // want configure:"wrapper, arg: 1, returns error"
+	return zconfig.Configure(ctx, s)
+}
+
+func twice(ctx context.Context) error {
+	var cfg Config
+	return errors.Join(
+		zconfig.Configure(ctx, &cfg),
+		configure(ctx, &cfg), // want "ZC503: struct testdata/src/multiple_configure.Config is already configured at multiple_configure.go:23"
+	)
+}
+
+func globalFirst(ctx context.Context) error {
+	return zconfig.Configure(ctx, &global)
+}
+
+func globalSecond(ctx context.Context) error {
+	return configure(ctx, &global) // want "ZC503: struct testdata/src/multiple_configure.Config is already configured at multiple_configure.go:29"
+}
+
+func throughInterface(ctx context.Context) error {
+	cfg := new(Config)
+	var s any = cfg
+	if err := zconfig.Configure(ctx, s); err != nil {
+		return err
+	}
+	return zconfig.DefaultProcessor.Process(ctx, cfg) // want "ZC503: struct testdata/src/multiple_configure.Config is already configured at multiple_configure.go:39"
+}
+
+func distinctAllocations(ctx context.Context) error {
+	for _, cfg := range []*Config{new(Config), new(Config)} {
+		if err := zconfig.Configure(ctx, cfg); err != nil {
+			return err
+		}
+	}
+	return zconfig.Configure(ctx, new(Config))
+}
+
+func branches(ctx context.Context, useWrapper bool) error {
+	var cfg Config
+	var err error
+	if useWrapper {
+		err = configure(ctx, &cfg)
+	} else {
+		err = zconfig.Configure(ctx, &cfg)
+	}
+	return err
+}
+
+func retry(ctx context.Context) error {
+	var cfg Config
+	if err := zconfig.Configure(ctx, &cfg); err != nil {
+		return configure(ctx, &cfg) // want "ZC503: struct testdata/src/multiple_configure.Config is already configured at multiple_configure.go:67"
+	}
+	return nil
+}
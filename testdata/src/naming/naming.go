@@ -0,0 +1,39 @@
+package naming
+
+type Database struct { // want Database:"<init:none>"
+	Host     string `key:"host"`
+	UserName string `key:"userName"`  // want "ZC108: key 'userName' of field UserName is not in kebab case, expected 'user-name'"
+	DBPort   int    `key:"DBPort"`    // want "ZC108: key 'DBPort' of field DBPort is not in kebab case, expected 'db-port'"
+	Password string `key:"pass_word"` /* want
+	"ZC108: key 'pass_word' of field Password is not in kebab case, expected 'pass-word'"
+	"ZC108: key 'pass_word' of field Password contains characters other than \\[a-zA-Z0-9-\\]"
+	*/
+}
+
+type Kafka struct { // want Kafka:"<init:none>"
+	Brokers string `key:"brokers"`
+	TLS     struct {
+		Cert string `key:"cert"`
+	} `key:"tls"`
+}
+
+type Config struct { // want Config:"<init:none>"
+	Database Database `key:"database"` /* want
+	"ZC108: key 'userName' of field UserName is not in kebab case, expected 'user-name'"
+	"ZC108: key 'DBPort' of field DBPort is not in kebab case, expected 'db-port'"
+	"ZC108: key 'pass_word' of field Password is not in kebab case, expected 'pass-word'"
+	"ZC108: key 'pass_word' of field Password contains characters other than \\[a-zA-Z0-9-\\]"
+	*/
+	Events struct { /* want
+		"ZC108: key 'kafka.tls.cert' of field Kafka.TLS.Cert has 3 levels, more than the maximum of 2"
+		"ZC108: key 'events.kafka.brokers' of field Events.Kafka.Brokers has 3 levels, more than the maximum of 2"
+		"ZC108: key 'events.kafka.tls.cert' of field Events.Kafka.TLS.Cert has 4 levels, more than the maximum of 2"
+		*/
+		Kafka Kafka `key:"kafka"` // want "ZC108: key 'kafka.tls.cert' of field Kafka.TLS.Cert has 3 levels, more than the maximum of 2"
+	} `key:"events"`
+	V2API       string   `key:"v2-api"`
+	ZconfigHost string   `key:"zconfig-host"` // want "ZC108: key 'zconfig-host' of field ZconfigHost starts with forbidden prefix 'zconfig'"
+	Internal    struct { // want "ZC108: key 'zconfig.token' of field Internal.Token starts with forbidden prefix 'zconfig'"
+		Token string `key:"token"`
+	} `key:"zconfig"`
+}
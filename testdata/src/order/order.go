@@ -0,0 +1,7 @@
+package order
+
+type Config struct { // want Config:"<init:none>"
+	NoKey int  `default:"1"`                         // want "default tag is used on field without key tag"
+	Empty bool `key:"" description:"" default:"yes"` // want "key tag cannot be empty" "description tag cannot be empty" "default tag is used on field without key tag"
+	Port  int  `key:"port" default:"eighty"`         // want "default value 'eighty' cannot be parsed as int"
+}
@@ -0,0 +1,25 @@
+package origins
+
+import (
+	"context"
+	"errors"
+
+	"github.com/synthesio/zconfig/v2"
+
+	"testdata/src/origins/subpackage"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Source *string `inject-as:"source"`
+}
+
+func Configure(ctx context.Context) error {
+	return errors.Join(
+		zconfig.Configure(ctx, new(subpackage.Repository)),                // want "ZC201: no source is provided for alias 'db' used by target fields: DB"
+		zconfig.Configure(ctx, new(subpackage.Injection[*bool, *string])), /* want
+		"ZC203: injection alias 'value': cannot inject source field 'Source \\*bool' into target field 'Target \\*string', mismatched types"
+		"ZC203: injection alias 'value': target field 'Target \\*string' cannot be injected with source field 'Source \\*bool', mismatched types"
+		*/
+		zconfig.Configure(ctx, new(Config)), // want "ZC205: no target is injected with alias 'source' provided by source fields: Source"
+	)
+}
@@ -0,0 +1,12 @@
+package subpackage
+
+// Repository expects its database to be injected by the struct embedding it
+type Repository struct { // want Repository:"<init:none>"
+	DB *string `inject:"db"`
+}
+
+// Injection injects its source into its target, whose types are only known once instantiated
+type Injection[S, T any] struct { // want Injection:"<init:none>"
+	Source S `inject-as:"value"`
+	Target T `inject:"value"`
+}
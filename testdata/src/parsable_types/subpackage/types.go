@@ -0,0 +1,9 @@
+package subpackage
+
+type Custom struct {
+	Value string
+}
+
+type NotAllowed struct {
+	Value string
+}
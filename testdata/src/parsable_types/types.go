@@ -0,0 +1,13 @@
+package parsable_types
+
+import "testdata/src/parsable_types/subpackage"
+
+type Port int
+
+type Parsable struct { // want Parsable:"<init:none>"
+	Port    Port                  `key:"port" default:"eighty"`
+	PtrPort *Port                 `key:"ptr-port"`
+	Custom  subpackage.Custom     `key:"custom"`
+	Map     map[string]string     `key:"map"`
+	Other   subpackage.NotAllowed `key:"other"` // want "type testdata/src/parsable_types/subpackage.NotAllowed is not handled by zconfig default parsers, configuring field Other will fail"
+}
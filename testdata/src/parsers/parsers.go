@@ -0,0 +1,41 @@
+package parsers
+
+import (
+	"math/big"
+	"net/netip"
+	"time"
+)
+
+type private interface {
+	do()
+}
+
+type Port int
+
+type Duration = time.Duration
+
+type Custom struct { // want Custom:"<init:none>"
+	Value string
+}
+
+type Parsers struct { // want Parsers:"<init:none>"
+	Chan      chan int          `key:"chan"`      // want "type chan int is not handled by zconfig default parsers, configuring field Chan will fail"
+	Func      func()            `key:"func"`      // want "type func\\(\\) is not handled by zconfig default parsers, configuring field Func will fail"
+	Interface private           `key:"interface"` // want "type testdata/src/parsers.private is not handled by zconfig default parsers, configuring field Interface will fail"
+	Any       any               `key:"any"`       // want "type any is not handled by zconfig default parsers, configuring field Any will fail"
+	Map       map[string]string `key:"map"`       // want "type map\\[string\\]string is not handled by zconfig default parsers, configuring field Map will fail"
+	Floats    []float64         `key:"floats"`    // want "type \\[\\]float64 is not handled by zconfig default parsers, configuring field Floats will fail"
+	Port      Port              `key:"port"`      // want "type testdata/src/parsers.Port is not handled by zconfig default parsers, configuring field Port will fail"
+	PtrPtr    **int             `key:"ptr-ptr"`   // want "type \\*\\*int is not handled by zconfig default parsers, configuring field PtrPtr will fail"
+	Leaf      Custom            `key:"leaf"`      // want "type testdata/src/parsers.Custom is not handled by zconfig default parsers, configuring field Leaf will fail"
+
+	Duration Duration    `key:"duration"`
+	Big      *big.Int    `key:"big"`
+	Addr     *netip.Addr `key:"addr"`
+	Bytes    []byte      `key:"bytes"`
+	Ints     []int       `key:"ints"`
+
+	// not configurable, no issue expected
+	NoKey chan int
+	Node  *Custom `inject:"node"`
+}
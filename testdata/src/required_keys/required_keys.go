@@ -0,0 +1,28 @@
+package required_keys
+
+import (
+	"context"
+	"time"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Database struct { // want Database:"<init:none>"
+	Host     string `key:"host"`
+	Port     int    `key:"port" default:"5432"`
+	Password string `key:"password"`
+}
+
+type Config struct { // want Config:"<init:none>"
+	Database Database      `key:"database"`
+	Timeout  time.Duration `key:"timeout" default:"5s"`
+	Debug    bool          `key:"debug"`
+	Token    string        `key:"token"`
+}
+
+func Configure(ctx context.Context) error {
+	return zconfig.Configure(ctx, new(Config)) /* want
+	"ZC107: key database.host \\(env DATABASE_HOST\\) of field Database.Host has no default value and must be provided"
+	"ZC107: key debug \\(env DEBUG\\) of field Debug has no default value and must be provided"
+	*/
+}
@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/synthesio/zconfig/v2"
+
+	"testdata/src/schema/subpackage"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Timeout  time.Duration        `key:"timeout" default:"5s" description:"request timeout"`
+	Database subpackage.Database  `key:"database"`
+	Replica  *subpackage.Database `key:"replica-db" inject-as:"replica"`
+	Client   Client               `key:"client"`
+}
+
+type Client struct { // want Client:"<init:none>"
+	Replica *subpackage.Database `inject:"replica"`
+	Names   []string             `key:"names"`
+}
+
+func Configure() error {
+	err := zconfig.Configure(context.Background(), new(Config))
+	return errors.Join(err, zconfig.Configure(context.Background(), new(struct {
+		Debug bool `key:"debug" default:"false"`
+	})))
+}
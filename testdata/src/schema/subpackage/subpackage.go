@@ -0,0 +1,7 @@
+package subpackage
+
+type Database struct {
+	Host     string `key:"host" description:"database host"`
+	Port     int    `key:"port" default:"5432"`
+	Password string `key:"password" default:""`
+}
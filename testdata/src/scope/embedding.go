@@ -0,0 +1,32 @@
+package scope
+
+import (
+	"context"
+	"errors"
+
+	"github.com/synthesio/zconfig/v2"
+
+	"testdata/src/scope/subpackage"
+)
+
+type Embedding struct { // want Embedding:"<init:none>"
+	subpackage.Base
+	Client *string `inject-as:"client"`
+}
+
+type MismatchedEmbedding struct { // want MismatchedEmbedding:"<init:none>"
+	subpackage.Base /* want
+	"ZC203: injection alias 'client': target field 'Base.Client \\*string' cannot be injected with source field 'Client \\*bool', mismatched types"
+	"ZC106: key 'database' defined by field 'Base.Database' is similar to key 'databse' used by field 'Databse', did you mean 'databse'\\?"
+	*/
+	Client  *bool  `inject-as:"client"` // want "ZC203: injection alias 'client': cannot inject source field 'Client \\*bool' into target field 'Base.Client \\*string', mismatched types"
+	Databse string `key:"databse"`      // want "ZC106: key 'databse' defined by field 'Databse' is similar to key 'database' used by field 'Base.Database', did you mean 'database'\\?"
+}
+
+func ConfigureEmbedding(ctx context.Context) error {
+	return errors.Join(
+		zconfig.Configure(ctx, new(Embedding)),
+		zconfig.Configure(ctx, new(MismatchedEmbedding)),
+		zconfig.Configure(ctx, new(subpackage.Base)), // want "ZC201: no source is provided for alias 'client' used by target fields: Client"
+	)
+}
@@ -0,0 +1,30 @@
+package scope
+
+import (
+	"context"
+	"errors"
+
+	"github.com/synthesio/zconfig/v2"
+
+	"testdata/src/scope/subpackage"
+)
+
+type Local[T any] struct { // want Local:"<init:none>"
+	Source *bool `inject-as:"local"`
+	Target T     `inject:"local"`
+}
+
+func Configure(ctx context.Context) error {
+	return errors.Join(
+		zconfig.Configure(ctx, new(subpackage.Injection[*bool, *bool])),
+		zconfig.Configure(ctx, new(subpackage.Injection[*bool, *string])), /* want
+		"ZC203: injection alias 'value': cannot inject source field 'Source \\*bool' into target field 'Nested.Target \\*string', mismatched types"
+		"ZC203: injection alias 'value': target field 'Nested.Target \\*string' cannot be injected with source field 'Source \\*bool', mismatched types"
+		*/
+		zconfig.Configure(ctx, new(Local[*bool])),
+		zconfig.Configure(ctx, new(Local[*int])), /* want
+		"ZC203: injection alias 'local': cannot inject source field 'Source \\*bool' into target field 'Target \\*int', mismatched types"
+		"ZC203: injection alias 'local': target field 'Target \\*int' cannot be injected with source field 'Source \\*bool', mismatched types"
+		*/
+	)
+}
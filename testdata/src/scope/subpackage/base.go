@@ -0,0 +1,7 @@
+package subpackage
+
+// Base is embedded by configuration roots of other packages, which provide the source of its target
+type Base struct { // want Base:"<init:none>"
+	Client   *string `inject:"client"`
+	Database string  `key:"database"`
+}
@@ -0,0 +1,11 @@
+package subpackage
+
+// Injection injects its source into its target, whose types are only known once instantiated
+type Injection[S, T any] struct { // want Injection:"<init:none>"
+	Source S `inject-as:"value"`
+	Nested Nested[T]
+}
+
+type Nested[T any] struct { // want Nested:"<init:none>"
+	Target T `inject:"value"`
+}
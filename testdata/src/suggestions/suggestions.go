@@ -0,0 +1,32 @@
+package suggestions
+
+import (
+	"context"
+
+	"github.com/synthesio/zconfig/v2"
+)
+
+type Config struct { // want Config:"<init:none>"
+	Database *bool `inject-as:"database"`
+	Cache    *bool `inject-as:"cache"`
+	Target   *bool `inject:"databse"`
+	Cached   *bool `inject:"cache"`
+	Unknown  *bool `inject:"queue"`
+
+	Timeout   int    `key:"timeout"`   // want "ZC106: key 'timeout' defined by field 'Timeout' is similar to key 'timout' used by field 'Timout', did you mean 'timout'\\?"
+	Timout    int    `key:"timout"`    // want "ZC106: key 'timout' defined by field 'Timout' is similar to key 'timeout' used by field 'Timeout', did you mean 'timeout'\\?"
+	HostName  string `key:"hostname"`  // want "ZC106: key 'hostname' defined by field 'HostName' is similar to key 'host-name' used by field 'HostName2', did you mean 'host-name'\\?"
+	HostName2 string `key:"host-name"` // want "ZC106: key 'host-name' defined by field 'HostName2' is similar to key 'hostname' used by field 'HostName', did you mean 'hostname'\\?"
+	Server1   string `key:"server1"`
+	Server2   string `key:"server2"`
+	Port      int    `key:"port"`
+	Sort      int    `key:"sort"`
+}
+
+func Configure(ctx context.Context) error {
+	return zconfig.Configure(ctx, new(Config)) /* want
+	"ZC201: no source is provided for alias 'databse' used by target fields: Target, did you mean 'database'\\?"
+	"ZC201: no source is provided for alias 'queue' used by target fields: Unknown"
+	"ZC205: no target is injected with alias 'database' provided by source fields: Database"
+	*/
+}
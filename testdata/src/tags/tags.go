@@ -50,6 +50,25 @@ type IncompatibleTags struct { // want IncompatibleTags:"<init:none>"
 	D *bool `inject-as:"d" inject:"d"` // want "inject and inject-as tags cannot be used on the same field"
 }
 
+type KeySyntax struct { // want KeySyntax:"<init:none>"
+	Equal bool `key:"a=b"` /* want
+	"ZC006: key 'a=b' cannot be looked up by the args provider, because '=' and whitespace separate --key=value arguments"
+	"ZC006: key 'a=b' cannot be looked up by the env provider, because 'A=B' is not a valid environment variable name"
+	*/
+	Space bool `key:"a b"` /* want
+	"ZC006: key 'a b' cannot be looked up by the args provider, because '=' and whitespace separate --key=value arguments"
+	"ZC006: key 'a b' cannot be looked up by the env provider, because 'A B' is not a valid environment variable name"
+	*/
+	Digit   bool `key:"2fa"`     // want "ZC006: key '2fa' cannot be looked up by the env provider, because '2FA' is not a valid environment variable name"
+	Leading bool `key:".c"`      // want "ZC006: key '.c' has an empty segment, because it starts or ends with a dot or contains consecutive dots"
+	Empty   bool `key:"d..e"`    // want "ZC006: key 'd..e' has an empty segment, because it starts or ends with a dot or contains consecutive dots"
+	Dotted  bool `key:"db.host"` // want "ZC006: key 'db.host' of field Dotted contains dots, which only separate the keys of nested structs"
+	Nested  struct {
+		Host bool `key:"host"`
+	} `key:"f.g"`
+	Snake bool `key:"snake_case-key"`
+}
+
 type AllOk struct { // want AllOk:"<init:none>"
 	A bool `key:"a"`
 	B *int `key:"b" inject-as:"b"`